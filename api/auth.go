@@ -0,0 +1,968 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EnclaveRunner/shareddeps/auth"
+)
+
+// ListUserGroups implements StrictServerInterface.
+func (s *Server) ListUserGroups(
+	ctx context.Context,
+	request ListUserGroupsRequestObject,
+) (ListUserGroupsResponseObject, error) {
+	page, err := s.authModule.ListUserGroupsPage(listOptions(
+		request.Params.Limit,
+		request.Params.Cursor,
+		request.Params.Prefix,
+		request.Params.Contains,
+	))
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return ListUserGroups400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return ListUserGroups200JSONResponse{
+		Body:    page.Items,
+		Headers: ListUserGroups200ResponseHeaders{page.NextCursor},
+	}, nil
+}
+
+// CreateUserGroup implements StrictServerInterface.
+func (s *Server) CreateUserGroup(
+	ctx context.Context,
+	request CreateUserGroupRequestObject,
+) (CreateUserGroupResponseObject, error) {
+	if request.Body.Name == "" {
+		return CreateUserGroup400JSONResponse{
+			BadRequestJSONResponse{Message: "group name must not be empty"},
+		}, nil
+	}
+
+	err := s.authModule.CreateUserGroupWithInfo(groupInfo(ctx, *request.Body))
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return CreateUserGroup400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.NotFoundError{}):
+		return CreateUserGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return CreateUserGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return CreateUserGroup204Response{}, nil
+}
+
+// GetUserGroup implements StrictServerInterface.
+func (s *Server) GetUserGroup(
+	ctx context.Context,
+	request GetUserGroupRequestObject,
+) (GetUserGroupResponseObject, error) {
+	page, err := s.authModule.GetUserGroupPage(request.Group, listOptions(
+		request.Params.Limit,
+		request.Params.Cursor,
+		request.Params.Prefix,
+		request.Params.Contains,
+	))
+	var members auth.NestedMembership
+	if err == nil {
+		members, err = s.authModule.GetUserGroupMembers(request.Group)
+	}
+	var info auth.GroupInfo
+	if err == nil {
+		info, err = s.authModule.GetUserGroupInfo(request.Group)
+	}
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return GetUserGroup400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.NotFoundError{}):
+		return GetUserGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return GetUserGroup200JSONResponse{
+		Body: withInfo(Group{
+			Name:             request.Group,
+			Members:          page.Items,
+			InheritedMembers: &members.Inherited,
+		}, info),
+		Headers: GetUserGroup200ResponseHeaders{page.NextCursor},
+	}, nil
+}
+
+// UpdateUserGroup implements StrictServerInterface.
+func (s *Server) UpdateUserGroup(
+	ctx context.Context,
+	request UpdateUserGroupRequestObject,
+) (UpdateUserGroupResponseObject, error) {
+	err := s.authModule.Update(func(tx *auth.Tx) error {
+		info, err := tx.GetUserGroupInfo(request.Group)
+		if err != nil {
+			return err
+		}
+
+		return tx.UpdateUserGroupInfo(updateInfo(info, *request.Body))
+	})
+	switch {
+	case errors.Is(err, &auth.NotFoundError{}):
+		return UpdateUserGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return UpdateUserGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return UpdateUserGroup204Response{}, nil
+}
+
+// DeleteUserGroup implements StrictServerInterface.
+func (s *Server) DeleteUserGroup(
+	ctx context.Context,
+	request DeleteUserGroupRequestObject,
+) (DeleteUserGroupResponseObject, error) {
+	err := s.authModule.RemoveUserGroup(request.Group)
+	switch {
+	case errors.Is(err, &auth.NotFoundError{}):
+		return DeleteUserGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return DeleteUserGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return DeleteUserGroup204Response{}, nil
+}
+
+// AddUserToGroup implements StrictServerInterface.
+func (s *Server) AddUserToGroup(
+	ctx context.Context,
+	request AddUserToGroupRequestObject,
+) (AddUserToGroupResponseObject, error) {
+	var err error
+	validity, bounded := toValidity(
+		request.Body.NotBefore,
+		request.Body.ExpiresAt,
+	)
+	if bounded {
+		err = s.authModule.AddTimeBoundUserToGroup(
+			request.Body.User,
+			validity,
+			request.Group,
+		)
+	} else {
+		err = s.authModule.AddUserToGroup(request.Body.User, request.Group)
+	}
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return AddUserToGroup400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.NotFoundError{}):
+		return AddUserToGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return AddUserToGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return AddUserToGroup204Response{}, nil
+}
+
+// AddUserGroupToGroup implements StrictServerInterface.
+func (s *Server) AddUserGroupToGroup(
+	ctx context.Context,
+	request AddUserGroupToGroupRequestObject,
+) (AddUserGroupToGroupResponseObject, error) {
+	err := s.authModule.AddUserGroupToGroup(request.Body.Group, request.Group)
+	switch {
+	case errors.Is(err, &auth.NotFoundError{}):
+		return AddUserGroupToGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return AddUserGroupToGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return AddUserGroupToGroup204Response{}, nil
+}
+
+// RemoveUserFromGroup implements StrictServerInterface.
+func (s *Server) RemoveUserFromGroup(
+	ctx context.Context,
+	request RemoveUserFromGroupRequestObject,
+) (RemoveUserFromGroupResponseObject, error) {
+	err := s.authModule.RemoveUserFromGroup(request.User, request.Group)
+	switch {
+	case errors.Is(err, &auth.NotFoundError{}):
+		return RemoveUserFromGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return RemoveUserFromGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return RemoveUserFromGroup204Response{}, nil
+}
+
+// RemoveUser implements StrictServerInterface.
+func (s *Server) RemoveUser(
+	ctx context.Context,
+	request RemoveUserRequestObject,
+) (RemoveUserResponseObject, error) {
+	err := s.authModule.RemoveUser(request.User)
+	switch {
+	case errors.Is(err, &auth.ConflictError{}):
+		return RemoveUser409JSONResponse{ConflictJSONResponse(errorBody(err))}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return RemoveUser204Response{}, nil
+}
+
+// GetGroupsForUser implements StrictServerInterface.
+func (s *Server) GetGroupsForUser(
+	ctx context.Context,
+	request GetGroupsForUserRequestObject,
+) (GetGroupsForUserResponseObject, error) {
+	groups, err := s.authModule.GetGroupsForUser(request.User)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return GetGroupsForUser200JSONResponse(groups), nil
+}
+
+// GetUserMemberships implements StrictServerInterface.
+func (s *Server) GetUserMemberships(
+	ctx context.Context,
+	request GetUserMembershipsRequestObject,
+) (GetUserMembershipsResponseObject, error) {
+	memberships, err := s.authModule.GetUserMemberships(request.User)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return GetUserMemberships200JSONResponse{
+		Direct:    memberships.Direct,
+		Inherited: memberships.Inherited,
+	}, nil
+}
+
+// ListResourceGroups implements StrictServerInterface.
+func (s *Server) ListResourceGroups(
+	ctx context.Context,
+	request ListResourceGroupsRequestObject,
+) (ListResourceGroupsResponseObject, error) {
+	page, err := s.authModule.ListResourceGroupsPage(listOptions(
+		request.Params.Limit,
+		request.Params.Cursor,
+		request.Params.Prefix,
+		request.Params.Contains,
+	))
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return ListResourceGroups400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return ListResourceGroups200JSONResponse{
+		Body:    page.Items,
+		Headers: ListResourceGroups200ResponseHeaders{page.NextCursor},
+	}, nil
+}
+
+// CreateResourceGroup implements StrictServerInterface.
+func (s *Server) CreateResourceGroup(
+	ctx context.Context,
+	request CreateResourceGroupRequestObject,
+) (CreateResourceGroupResponseObject, error) {
+	if request.Body.Name == "" {
+		return CreateResourceGroup400JSONResponse{
+			BadRequestJSONResponse{Message: "group name must not be empty"},
+		}, nil
+	}
+
+	err := s.authModule.CreateResourceGroupWithInfo(
+		groupInfo(ctx, *request.Body),
+	)
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return CreateResourceGroup400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.NotFoundError{}):
+		return CreateResourceGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return CreateResourceGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return CreateResourceGroup204Response{}, nil
+}
+
+// GetResourceGroup implements StrictServerInterface.
+func (s *Server) GetResourceGroup(
+	ctx context.Context,
+	request GetResourceGroupRequestObject,
+) (GetResourceGroupResponseObject, error) {
+	page, err := s.authModule.GetResourceGroupPage(request.Group, listOptions(
+		request.Params.Limit,
+		request.Params.Cursor,
+		request.Params.Prefix,
+		request.Params.Contains,
+	))
+	var info auth.GroupInfo
+	if err == nil {
+		info, err = s.authModule.GetResourceGroupInfo(request.Group)
+	}
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return GetResourceGroup400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.NotFoundError{}):
+		return GetResourceGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return GetResourceGroup200JSONResponse{
+		Body: withInfo(
+			Group{Name: request.Group, Members: page.Items},
+			info,
+		),
+		Headers: GetResourceGroup200ResponseHeaders{page.NextCursor},
+	}, nil
+}
+
+// UpdateResourceGroup implements StrictServerInterface.
+func (s *Server) UpdateResourceGroup(
+	ctx context.Context,
+	request UpdateResourceGroupRequestObject,
+) (UpdateResourceGroupResponseObject, error) {
+	err := s.authModule.Update(func(tx *auth.Tx) error {
+		info, err := tx.GetResourceGroupInfo(request.Group)
+		if err != nil {
+			return err
+		}
+
+		return tx.UpdateResourceGroupInfo(updateInfo(info, *request.Body))
+	})
+	switch {
+	case errors.Is(err, &auth.NotFoundError{}):
+		return UpdateResourceGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return UpdateResourceGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return UpdateResourceGroup204Response{}, nil
+}
+
+// DeleteResourceGroup implements StrictServerInterface.
+func (s *Server) DeleteResourceGroup(
+	ctx context.Context,
+	request DeleteResourceGroupRequestObject,
+) (DeleteResourceGroupResponseObject, error) {
+	err := s.authModule.RemoveResourceGroup(request.Group)
+	switch {
+	case errors.Is(err, &auth.NotFoundError{}):
+		return DeleteResourceGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return DeleteResourceGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return DeleteResourceGroup204Response{}, nil
+}
+
+// AddResourceToGroup implements StrictServerInterface.
+func (s *Server) AddResourceToGroup(
+	ctx context.Context,
+	request AddResourceToGroupRequestObject,
+) (AddResourceToGroupResponseObject, error) {
+	err := s.authModule.AddResourceToGroup(
+		request.Body.Resource,
+		request.Group,
+	)
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return AddResourceToGroup400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.NotFoundError{}):
+		return AddResourceToGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return AddResourceToGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return AddResourceToGroup204Response{}, nil
+}
+
+// RemoveResourceFromGroup implements StrictServerInterface.
+func (s *Server) RemoveResourceFromGroup(
+	ctx context.Context,
+	request RemoveResourceFromGroupRequestObject,
+) (RemoveResourceFromGroupResponseObject, error) {
+	err := s.authModule.RemoveResourceFromGroup(
+		request.Params.Resource,
+		request.Group,
+	)
+	switch {
+	case errors.Is(err, &auth.NotFoundError{}):
+		return RemoveResourceFromGroup404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return RemoveResourceFromGroup409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return RemoveResourceFromGroup204Response{}, nil
+}
+
+// RemoveResource implements StrictServerInterface.
+func (s *Server) RemoveResource(
+	ctx context.Context,
+	request RemoveResourceRequestObject,
+) (RemoveResourceResponseObject, error) {
+	err := s.authModule.RemoveResource(request.Params.Resource)
+	switch {
+	case errors.Is(err, &auth.ConflictError{}):
+		return RemoveResource409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return RemoveResource204Response{}, nil
+}
+
+// GetGroupsForResource implements StrictServerInterface.
+func (s *Server) GetGroupsForResource(
+	ctx context.Context,
+	request GetGroupsForResourceRequestObject,
+) (GetGroupsForResourceResponseObject, error) {
+	groups, err := s.authModule.GetGroupsForResource(request.Params.Resource)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return GetGroupsForResource200JSONResponse(groups), nil
+}
+
+// ListPolicies implements StrictServerInterface.
+func (s *Server) ListPolicies(
+	ctx context.Context,
+	request ListPoliciesRequestObject,
+) (ListPoliciesResponseObject, error) {
+	page, err := s.authModule.ListPoliciesPage(listOptions(
+		request.Params.Limit,
+		request.Params.Cursor,
+		request.Params.Prefix,
+		request.Params.Contains,
+	))
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return ListPolicies400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	policies := make([]Policy, 0, len(page.Items))
+	for _, policy := range page.Items {
+		listed := toPolicy(policy.Policy)
+		listed.NotBefore = timePtr(policy.NotBefore)
+		listed.ExpiresAt = timePtr(policy.ExpiresAt)
+		policies = append(policies, listed)
+	}
+
+	return ListPolicies200JSONResponse{
+		Body:    policies,
+		Headers: ListPolicies200ResponseHeaders{page.NextCursor},
+	}, nil
+}
+
+// ListTimeBoundMemberships implements StrictServerInterface.
+func (s *Server) ListTimeBoundMemberships(
+	ctx context.Context,
+	request ListTimeBoundMembershipsRequestObject,
+) (ListTimeBoundMembershipsResponseObject, error) {
+	memberships, err := s.authModule.ListTimeBoundMemberships()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	response := make(ListTimeBoundMemberships200JSONResponse, 0, len(memberships))
+	for _, membership := range memberships {
+		response = append(response, TimeBoundMembership{
+			Group:     membership.Group,
+			Member:    membership.Member,
+			NotBefore: timePtr(membership.NotBefore),
+			ExpiresAt: timePtr(membership.ExpiresAt),
+		})
+	}
+
+	return response, nil
+}
+
+// AddPolicy implements StrictServerInterface.
+func (s *Server) AddPolicy(
+	ctx context.Context,
+	request AddPolicyRequestObject,
+) (AddPolicyResponseObject, error) {
+	policyEffect := effect(request.Body.Effect)
+	add, ok := map[auth.Effect]func(string, string, string) error{
+		auth.EffectAllow: s.authModule.AddPolicy,
+		auth.EffectDeny:  s.authModule.AddDenyPolicy,
+	}[policyEffect]
+	if !ok {
+		return AddPolicy400JSONResponse{
+			BadRequestJSONResponse{Message: "effect must be allow or deny"},
+		}, nil
+	}
+
+	var err error
+	validity, bounded := toValidity(
+		request.Body.NotBefore,
+		request.Body.ExpiresAt,
+	)
+	if bounded {
+		err = s.authModule.AddTimeBoundPolicy(auth.Policy{
+			UserGroup:     request.Body.UserGroup,
+			ResourceGroup: request.Body.ResourceGroup,
+			Permission:    request.Body.Permission,
+			Effect:        policyEffect,
+		}, validity)
+	} else {
+		err = add(
+			request.Body.UserGroup,
+			request.Body.ResourceGroup,
+			request.Body.Permission,
+		)
+	}
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return AddPolicy400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.NotFoundError{}):
+		return AddPolicy404JSONResponse{NotFoundJSONResponse(errorBody(err))}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return AddPolicy409JSONResponse{ConflictJSONResponse(errorBody(err))}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return AddPolicy204Response{}, nil
+}
+
+// RemovePolicy implements StrictServerInterface.
+func (s *Server) RemovePolicy(
+	ctx context.Context,
+	request RemovePolicyRequestObject,
+) (RemovePolicyResponseObject, error) {
+	remove, ok := map[auth.Effect]func(string, string, string) error{
+		auth.EffectAllow: s.authModule.RemovePolicy,
+		auth.EffectDeny:  s.authModule.RemoveDenyPolicy,
+	}[effect(request.Params.Effect)]
+	if !ok {
+		return RemovePolicy400JSONResponse{
+			BadRequestJSONResponse{Message: "effect must be allow or deny"},
+		}, nil
+	}
+
+	err := remove(
+		request.Params.UserGroup,
+		request.Params.ResourceGroup,
+		request.Params.Permission,
+	)
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return RemovePolicy400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return RemovePolicy409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return RemovePolicy204Response{}, nil
+}
+
+// ExplainDecision implements StrictServerInterface.
+func (s *Server) ExplainDecision(
+	ctx context.Context,
+	request ExplainDecisionRequestObject,
+) (ExplainDecisionResponseObject, error) {
+	explanation, err := s.authModule.ExplainInTenant(
+		requestedTenant(request.Params.Tenant),
+		request.Params.User,
+		request.Params.Resource,
+		request.Params.Action,
+	)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	nearMisses := make([]NearMiss, 0, len(explanation.NearMisses))
+	for _, nearMiss := range explanation.NearMisses {
+		nearMisses = append(nearMisses, NearMiss{
+			Policy:   toPolicy(nearMiss.Policy),
+			Mismatch: PolicyField(nearMiss.Mismatch),
+		})
+	}
+
+	response := ExplainDecision200JSONResponse{
+		Allowed:        explanation.Allowed,
+		User:           explanation.User,
+		Tenant:         tenantPtr(explanation.Tenant),
+		Resource:       explanation.Resource,
+		Action:         explanation.Action,
+		UserGroups:     explanation.UserGroups,
+		ResourceGroups: explanation.ResourceGroups,
+		NearMisses:     nearMisses,
+	}
+	if explanation.MatchedPolicy != nil {
+		matchedPolicy := toPolicy(*explanation.MatchedPolicy)
+		response.MatchedPolicy = &matchedPolicy
+	}
+
+	return response, nil
+}
+
+// GetEffectivePermissions implements StrictServerInterface.
+func (s *Server) GetEffectivePermissions(
+	ctx context.Context,
+	request GetEffectivePermissionsRequestObject,
+) (GetEffectivePermissionsResponseObject, error) {
+	report, err := s.authModule.EffectivePermissions(request.User)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	if request.Params.Format != nil && *request.Params.Format == ReportFormatCSV {
+		var body bytes.Buffer
+		err = report.WriteCSV(&body)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Handled by the strict handler
+		}
+
+		return GetEffectivePermissions200TextcsvResponse{
+			Body:          &body,
+			ContentLength: int64(body.Len()),
+		}, nil
+	}
+
+	permissions := make([]EffectivePermission, 0, len(report.Permissions))
+	for _, permission := range report.Permissions {
+		permissions = append(permissions, EffectivePermission{
+			Resource: permission.Resource,
+			Method:   permission.Method,
+			Policy:   toPolicy(permission.Policy),
+		})
+	}
+
+	return GetEffectivePermissions200JSONResponse{
+		User:        report.User,
+		Permissions: permissions,
+	}, nil
+}
+
+// WhoCanAccess implements StrictServerInterface.
+func (s *Server) WhoCanAccess(
+	ctx context.Context,
+	request WhoCanAccessRequestObject,
+) (WhoCanAccessResponseObject, error) {
+	access, err := s.authModule.WhoCanAccessInTenant(
+		requestedTenant(request.Params.Tenant),
+		request.Params.Resource,
+		request.Params.Method,
+	)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	policies := make([]Policy, 0, len(access.Policies))
+	for _, policy := range access.Policies {
+		policies = append(policies, toPolicy(policy))
+	}
+
+	return WhoCanAccess200JSONResponse{
+		Resource:         access.Resource,
+		Method:           access.Method,
+		Tenant:           tenantPtr(access.Tenant),
+		Everyone:         access.Everyone,
+		UserGroups:       access.UserGroups,
+		Users:            access.Users,
+		DeniedUserGroups: access.DeniedUserGroups,
+		DeniedUsers:      access.DeniedUsers,
+		Policies:         policies,
+	}, nil
+}
+
+// ListTenants implements StrictServerInterface.
+func (s *Server) ListTenants(
+	ctx context.Context,
+	request ListTenantsRequestObject,
+) (ListTenantsResponseObject, error) {
+	tenants, err := s.authModule.ListTenants()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return ListTenants200JSONResponse(tenants), nil
+}
+
+// CreateTenant implements StrictServerInterface.
+func (s *Server) CreateTenant(
+	ctx context.Context,
+	request CreateTenantRequestObject,
+) (CreateTenantResponseObject, error) {
+	err := s.authModule.CreateTenant(request.Body.Name)
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return CreateTenant400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return CreateTenant204Response{}, nil
+}
+
+// DeleteTenant implements StrictServerInterface.
+func (s *Server) DeleteTenant(
+	ctx context.Context,
+	request DeleteTenantRequestObject,
+) (DeleteTenantResponseObject, error) {
+	err := s.authModule.RemoveTenant(request.Tenant)
+	switch {
+	case errors.Is(err, &auth.ValidationError{}):
+		return DeleteTenant400JSONResponse{
+			BadRequestJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.NotFoundError{}):
+		return DeleteTenant404JSONResponse{
+			NotFoundJSONResponse(errorBody(err)),
+		}, nil
+	case errors.Is(err, &auth.ConflictError{}):
+		return DeleteTenant409JSONResponse{
+			ConflictJSONResponse(errorBody(err)),
+		}, nil
+	case err != nil:
+		return nil, err //nolint:wrapcheck // Handled by the strict handler
+	}
+
+	return DeleteTenant204Response{}, nil
+}
+
+func toPolicy(policy auth.Policy) Policy {
+	policyEffect := PolicyEffect(policy.Effect)
+
+	return Policy{
+		UserGroup:     policy.UserGroup,
+		ResourceGroup: policy.ResourceGroup,
+		Permission:    policy.Permission,
+		Effect:        &policyEffect,
+	}
+}
+
+// effect returns the requested policy effect, allow if none is given.
+func effect(policyEffect *PolicyEffect) auth.Effect {
+	if policyEffect == nil {
+		return auth.EffectAllow
+	}
+
+	return auth.Effect(*policyEffect)
+}
+
+// toValidity returns the requested validity and whether it bounds anything.
+func toValidity(notBefore, expiresAt *time.Time) (auth.Validity, bool) {
+	var validity auth.Validity
+	if notBefore != nil {
+		validity.NotBefore = *notBefore
+	}
+	if expiresAt != nil {
+		validity.ExpiresAt = *expiresAt
+	}
+
+	return validity, notBefore != nil || expiresAt != nil
+}
+
+// listOptions returns the options of a listing request.
+func listOptions(
+	limit *int,
+	cursor, prefix, contains *string,
+) auth.ListOptions {
+	opts := auth.ListOptions{}
+	if limit != nil {
+		opts.Limit = *limit
+	}
+	if cursor != nil {
+		opts.Cursor = *cursor
+	}
+	if prefix != nil {
+		opts.Prefix = *prefix
+	}
+	if contains != nil {
+		opts.Contains = *contains
+	}
+
+	return opts
+}
+
+// requestedTenant returns the requested tenant, "" if none is given.
+func requestedTenant(requested *string) string {
+	if requested == nil {
+		return ""
+	}
+
+	return *requested
+}
+
+// tenantPtr returns nil for requests made without a tenant.
+func tenantPtr(tenant string) *string {
+	if tenant == "" {
+		return nil
+	}
+
+	return &tenant
+}
+
+// timePtr returns nil for the zero time, which leaves a Validity open.
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// textPtr returns nil for empty text, which is left out of responses.
+func textPtr(text string) *string {
+	if text == "" {
+		return nil
+	}
+
+	return &text
+}
+
+// groupInfo returns the metadata of a group created by the authenticated
+// user of ctx.
+func groupInfo(ctx context.Context, body CreateGroupRequest) auth.GroupInfo {
+	info := auth.GroupInfo{Name: body.Name}
+	if body.Description != nil {
+		info.Description = *body.Description
+	}
+	if body.Owner != nil {
+		info.Owner = *body.Owner
+	}
+	if user := auth.GetAuthenticatedUser(ctx); user != auth.UnauthenticatedUser {
+		info.CreatedBy = user
+	}
+
+	return info
+}
+
+// updateInfo applies the fields set in body to info.
+func updateInfo(info auth.GroupInfo, body UpdateGroupRequest) auth.GroupInfo {
+	if body.Description != nil {
+		info.Description = *body.Description
+	}
+	if body.Owner != nil {
+		info.Owner = *body.Owner
+	}
+
+	return info
+}
+
+// withInfo returns group with the metadata of info.
+func withInfo(group Group, info auth.GroupInfo) Group {
+	group.Description = textPtr(info.Description)
+	group.Owner = textPtr(info.Owner)
+	group.CreatedAt = timePtr(info.CreatedAt)
+	group.CreatedBy = textPtr(info.CreatedBy)
+
+	return group
+}
+
+func errorBody(err error) Error {
+	return Error{Message: err.Error()}
+}
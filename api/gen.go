@@ -8,19 +8,451 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
 )
 
+// Defines values for PolicyEffect.
+const (
+	PolicyEffectAllow PolicyEffect = "allow"
+	PolicyEffectDeny  PolicyEffect = "deny"
+)
+
+// Defines values for PolicyField.
+const (
+	PolicyFieldPermission    PolicyField = "permission"
+	PolicyFieldResourceGroup PolicyField = "resourceGroup"
+	PolicyFieldUserGroup     PolicyField = "userGroup"
+)
+
+// Defines values for ReportFormat.
+const (
+	ReportFormatCSV  ReportFormat = "csv"
+	ReportFormatJSON ReportFormat = "json"
+)
+
+// AddGroupRequest defines model for AddGroupRequest.
+type AddGroupRequest struct {
+	Group string `json:"group"`
+}
+
+// AddResourceRequest defines model for AddResourceRequest.
+type AddResourceRequest struct {
+	Resource string `json:"resource"`
+}
+
+// AddUserRequest defines model for AddUserRequest.
+type AddUserRequest struct {
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	User      string     `json:"user"`
+}
+
+// CreateGroupRequest defines model for CreateGroupRequest.
+type CreateGroupRequest struct {
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name"`
+	Owner       *string `json:"owner,omitempty"`
+}
+
+// EffectivePermission defines model for EffectivePermission.
+type EffectivePermission struct {
+	Method   string `json:"method"`
+	Policy   Policy `json:"policy"`
+	Resource string `json:"resource"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// Explanation defines model for Explanation.
+type Explanation struct {
+	Action         string     `json:"action"`
+	Allowed        bool       `json:"allowed"`
+	MatchedPolicy  *Policy    `json:"matchedPolicy,omitempty"`
+	NearMisses     []NearMiss `json:"nearMisses"`
+	Resource       string     `json:"resource"`
+	ResourceGroups []string   `json:"resourceGroups"`
+	Tenant         *string    `json:"tenant,omitempty"`
+	User           string     `json:"user"`
+	UserGroups     []string   `json:"userGroups"`
+}
+
+// Group defines model for Group.
+type Group struct {
+	// CreatedAt Not set for groups created before groups had metadata.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// CreatedBy User who created the group, not set if unknown.
+	CreatedBy   *string `json:"createdBy,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// InheritedMembers Members of nested user groups that are not direct members. Not set for resource groups.
+	InheritedMembers *[]string `json:"inheritedMembers,omitempty"`
+	Members          []string  `json:"members"`
+	Name             string    `json:"name"`
+
+	// Owner User responsible for the group. Owning a group grants no permissions.
+	Owner *string `json:"owner,omitempty"`
+}
+
+// NearMiss defines model for NearMiss.
+type NearMiss struct {
+	// Mismatch Names one of the three parts of a policy
+	Mismatch PolicyField `json:"mismatch"`
+	Policy   Policy      `json:"policy"`
+}
+
+// NestedMembership defines model for NestedMembership.
+type NestedMembership struct {
+	Direct    []string `json:"direct"`
+	Inherited []string `json:"inherited"`
+}
+
+// PermissionReport defines model for PermissionReport.
+type PermissionReport struct {
+	Permissions []EffectivePermission `json:"permissions"`
+	User        string                `json:"user"`
+}
+
+// Policy defines model for Policy.
+type Policy struct {
+	// Effect Whether a policy allows or denies the requests it matches
+	Effect *PolicyEffect `json:"effect,omitempty"`
+
+	// ExpiresAt The policy is removed at this time
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// NotBefore The policy is not in effect before this time
+	NotBefore     *time.Time `json:"notBefore,omitempty"`
+	Permission    string     `json:"permission"`
+	ResourceGroup string     `json:"resourceGroup"`
+	UserGroup     string     `json:"userGroup"`
+}
+
+// PolicyEffect Whether a policy allows or denies the requests it matches
+type PolicyEffect string
+
+// PolicyField Names one of the three parts of a policy
+type PolicyField string
+
+// ReportFormat Output format of a report
+type ReportFormat string
+
+// ResourceAccess defines model for ResourceAccess.
+type ResourceAccess struct {
+	// DeniedUserGroups User groups a deny policy applies to, "*" if it applies to everyone
+	DeniedUserGroups []string `json:"deniedUserGroups"`
+	DeniedUsers      []string `json:"deniedUsers"`
+
+	// Everyone True if a policy for all users allows access and none denies it
+	Everyone   bool     `json:"everyone"`
+	Method     string   `json:"method"`
+	Policies   []Policy `json:"policies"`
+	Resource   string   `json:"resource"`
+	Tenant     *string  `json:"tenant,omitempty"`
+	UserGroups []string `json:"userGroups"`
+	Users      []string `json:"users"`
+}
+
+// TimeBoundMembership defines model for TimeBoundMembership.
+type TimeBoundMembership struct {
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Group     string     `json:"group"`
+	Member    string     `json:"member"`
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+}
+
+// UpdateGroupRequest defines model for UpdateGroupRequest.
+type UpdateGroupRequest struct {
+	Description *string `json:"description,omitempty"`
+	Owner       *string `json:"owner,omitempty"`
+}
+
+// Contains defines model for Contains.
+type Contains = string
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// GroupName defines model for GroupName.
+type GroupName = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// Prefix defines model for Prefix.
+type Prefix = string
+
+// ResourceName defines model for ResourceName.
+type ResourceName = string
+
+// UserName defines model for UserName.
+type UserName = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Error
+
+// Conflict defines model for Conflict.
+type Conflict = Error
+
+// NotFound defines model for NotFound.
+type NotFound = Error
+
+// WhoCanAccessParams defines parameters for WhoCanAccess.
+type WhoCanAccessParams struct {
+	Resource string `form:"resource" json:"resource"`
+	Method   string `form:"method" json:"method"`
+
+	// Tenant Tenant the request is made in. Without it only policies that apply to all tenants are taken into account.
+	Tenant *string `form:"tenant,omitempty" json:"tenant,omitempty"`
+}
+
+// ExplainDecisionParams defines parameters for ExplainDecision.
+type ExplainDecisionParams struct {
+	User     string `form:"user" json:"user"`
+	Resource string `form:"resource" json:"resource"`
+	Action   string `form:"action" json:"action"`
+
+	// Tenant Tenant the request is made in. Without it only policies that apply to all tenants are taken into account.
+	Tenant *string `form:"tenant,omitempty" json:"tenant,omitempty"`
+}
+
+// RemovePolicyParams defines parameters for RemovePolicy.
+type RemovePolicyParams struct {
+	UserGroup     string        `form:"userGroup" json:"userGroup"`
+	ResourceGroup string        `form:"resourceGroup" json:"resourceGroup"`
+	Permission    string        `form:"permission" json:"permission"`
+	Effect        *PolicyEffect `form:"effect,omitempty" json:"effect,omitempty"`
+}
+
+// ListPoliciesParams defines parameters for ListPolicies.
+type ListPoliciesParams struct {
+	// Limit Maximum number of entries of the page, all if omitted.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Continues the listing after the page that returned it in the X-Next-Cursor header.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Prefix Only lists entries with a name starting with the prefix.
+	Prefix *Prefix `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Contains Only lists entries with a name containing the value.
+	Contains *Contains `form:"contains,omitempty" json:"contains,omitempty"`
+}
+
+// ListResourceGroupsParams defines parameters for ListResourceGroups.
+type ListResourceGroupsParams struct {
+	// Limit Maximum number of entries of the page, all if omitted.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Continues the listing after the page that returned it in the X-Next-Cursor header.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Prefix Only lists entries with a name starting with the prefix.
+	Prefix *Prefix `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Contains Only lists entries with a name containing the value.
+	Contains *Contains `form:"contains,omitempty" json:"contains,omitempty"`
+}
+
+// GetResourceGroupParams defines parameters for GetResourceGroup.
+type GetResourceGroupParams struct {
+	// Limit Maximum number of entries of the page, all if omitted.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Continues the listing after the page that returned it in the X-Next-Cursor header.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Prefix Only lists entries with a name starting with the prefix.
+	Prefix *Prefix `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Contains Only lists entries with a name containing the value.
+	Contains *Contains `form:"contains,omitempty" json:"contains,omitempty"`
+}
+
+// RemoveResourceFromGroupParams defines parameters for RemoveResourceFromGroup.
+type RemoveResourceFromGroupParams struct {
+	Resource ResourceName `form:"resource" json:"resource"`
+}
+
+// RemoveResourceParams defines parameters for RemoveResource.
+type RemoveResourceParams struct {
+	Resource ResourceName `form:"resource" json:"resource"`
+}
+
+// GetGroupsForResourceParams defines parameters for GetGroupsForResource.
+type GetGroupsForResourceParams struct {
+	Resource ResourceName `form:"resource" json:"resource"`
+}
+
+// ListUserGroupsParams defines parameters for ListUserGroups.
+type ListUserGroupsParams struct {
+	// Limit Maximum number of entries of the page, all if omitted.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Continues the listing after the page that returned it in the X-Next-Cursor header.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Prefix Only lists entries with a name starting with the prefix.
+	Prefix *Prefix `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Contains Only lists entries with a name containing the value.
+	Contains *Contains `form:"contains,omitempty" json:"contains,omitempty"`
+}
+
+// GetUserGroupParams defines parameters for GetUserGroup.
+type GetUserGroupParams struct {
+	// Limit Maximum number of entries of the page, all if omitted.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Continues the listing after the page that returned it in the X-Next-Cursor header.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Prefix Only lists entries with a name starting with the prefix.
+	Prefix *Prefix `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Contains Only lists entries with a name containing the value.
+	Contains *Contains `form:"contains,omitempty" json:"contains,omitempty"`
+}
+
+// GetEffectivePermissionsParams defines parameters for GetEffectivePermissions.
+type GetEffectivePermissionsParams struct {
+	Format *ReportFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// AddPolicyJSONRequestBody defines body for AddPolicy for application/json ContentType.
+type AddPolicyJSONRequestBody = Policy
+
+// CreateResourceGroupJSONRequestBody defines body for CreateResourceGroup for application/json ContentType.
+type CreateResourceGroupJSONRequestBody = CreateGroupRequest
+
+// UpdateResourceGroupJSONRequestBody defines body for UpdateResourceGroup for application/json ContentType.
+type UpdateResourceGroupJSONRequestBody = UpdateGroupRequest
+
+// AddResourceToGroupJSONRequestBody defines body for AddResourceToGroup for application/json ContentType.
+type AddResourceToGroupJSONRequestBody = AddResourceRequest
+
+// CreateTenantJSONRequestBody defines body for CreateTenant for application/json ContentType.
+type CreateTenantJSONRequestBody = CreateGroupRequest
+
+// CreateUserGroupJSONRequestBody defines body for CreateUserGroup for application/json ContentType.
+type CreateUserGroupJSONRequestBody = CreateGroupRequest
+
+// UpdateUserGroupJSONRequestBody defines body for UpdateUserGroup for application/json ContentType.
+type UpdateUserGroupJSONRequestBody = UpdateGroupRequest
+
+// AddUserGroupToGroupJSONRequestBody defines body for AddUserGroupToGroup for application/json ContentType.
+type AddUserGroupToGroupJSONRequestBody = AddGroupRequest
+
+// AddUserToGroupJSONRequestBody defines body for AddUserToGroup for application/json ContentType.
+type AddUserToGroupJSONRequestBody = AddUserRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Who can access a resource
+	// (GET /auth/access)
+	WhoCanAccess(c *gin.Context, params WhoCanAccessParams)
+	// Explain an authorization decision
+	// (GET /auth/explain)
+	ExplainDecision(c *gin.Context, params ExplainDecisionParams)
+	// Remove a policy
+	// (DELETE /auth/policies)
+	RemovePolicy(c *gin.Context, params RemovePolicyParams)
+	// List policies
+	// (GET /auth/policies)
+	ListPolicies(c *gin.Context, params ListPoliciesParams)
+	// Add a policy
+	// (POST /auth/policies)
+	AddPolicy(c *gin.Context)
+	// List resource groups
+	// (GET /auth/resource-groups)
+	ListResourceGroups(c *gin.Context, params ListResourceGroupsParams)
+	// Create a resource group
+	// (POST /auth/resource-groups)
+	CreateResourceGroup(c *gin.Context)
+	// Delete a resource group
+	// (DELETE /auth/resource-groups/{group})
+	DeleteResourceGroup(c *gin.Context, group GroupName)
+	// Get a resource group and its resources
+	// (GET /auth/resource-groups/{group})
+	GetResourceGroup(c *gin.Context, group GroupName, params GetResourceGroupParams)
+	// Update the metadata of a resource group
+	// (PATCH /auth/resource-groups/{group})
+	UpdateResourceGroup(c *gin.Context, group GroupName)
+	// Remove a resource from a resource group
+	// (DELETE /auth/resource-groups/{group}/resources)
+	RemoveResourceFromGroup(c *gin.Context, group GroupName, params RemoveResourceFromGroupParams)
+	// Add a resource to a resource group
+	// (POST /auth/resource-groups/{group}/resources)
+	AddResourceToGroup(c *gin.Context, group GroupName)
+	// Remove a resource from all resource groups
+	// (DELETE /auth/resources)
+	RemoveResource(c *gin.Context, params RemoveResourceParams)
+	// List the resource groups of a resource
+	// (GET /auth/resources/groups)
+	GetGroupsForResource(c *gin.Context, params GetGroupsForResourceParams)
+	// List tenants
+	// (GET /auth/tenants)
+	ListTenants(c *gin.Context)
+	// Create a tenant
+	// (POST /auth/tenants)
+	CreateTenant(c *gin.Context)
+	// Delete a tenant
+	// (DELETE /auth/tenants/{tenant})
+	DeleteTenant(c *gin.Context, tenant string)
+	// List time-bound user group memberships
+	// (GET /auth/time-bound-memberships)
+	ListTimeBoundMemberships(c *gin.Context)
+	// List user groups
+	// (GET /auth/user-groups)
+	ListUserGroups(c *gin.Context, params ListUserGroupsParams)
+	// Create a user group
+	// (POST /auth/user-groups)
+	CreateUserGroup(c *gin.Context)
+	// Delete a user group
+	// (DELETE /auth/user-groups/{group})
+	DeleteUserGroup(c *gin.Context, group GroupName)
+	// Get a user group and its members
+	// (GET /auth/user-groups/{group})
+	GetUserGroup(c *gin.Context, group GroupName, params GetUserGroupParams)
+	// Update the metadata of a user group
+	// (PATCH /auth/user-groups/{group})
+	UpdateUserGroup(c *gin.Context, group GroupName)
+	// Add a user group to a user group
+	// (POST /auth/user-groups/{group}/groups)
+	AddUserGroupToGroup(c *gin.Context, group GroupName)
+	// Add a user to a user group
+	// (POST /auth/user-groups/{group}/users)
+	AddUserToGroup(c *gin.Context, group GroupName)
+	// Remove a user from a user group
+	// (DELETE /auth/user-groups/{group}/users/{user})
+	RemoveUserFromGroup(c *gin.Context, group GroupName, user UserName)
+	// Remove a user from all user groups
+	// (DELETE /auth/users/{user})
+	RemoveUser(c *gin.Context, user UserName)
+	// List the user groups of a user
+	// (GET /auth/users/{user}/groups)
+	GetGroupsForUser(c *gin.Context, user UserName)
+	// List the direct and inherited user groups of a user
+	// (GET /auth/users/{user}/memberships)
+	GetUserMemberships(c *gin.Context, user UserName)
+	// Effective permissions of a user
+	// (GET /auth/users/{user}/permissions)
+	GetEffectivePermissions(c *gin.Context, user string, params GetEffectivePermissionsParams)
 	// Health Check
 	// (GET /health)
 	GetHealth(c *gin.Context)
@@ -35,8 +467,51 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(c *gin.Context) {
+// WhoCanAccess operation middleware
+func (siw *ServerInterfaceWrapper) WhoCanAccess(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WhoCanAccessParams
+
+	// ------------- Required query parameter "resource" -------------
+
+	if paramValue := c.Query("resource"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument resource is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "resource", c.Request.URL.Query(), &params.Resource)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "method" -------------
+
+	if paramValue := c.Query("method"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument method is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "method", c.Request.URL.Query(), &params.Method)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter method: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tenant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tenant", c.Request.URL.Query(), &params.Tenant)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tenant: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -45,71 +520,3200 @@ func (siw *ServerInterfaceWrapper) GetHealth(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetHealth(c)
+	siw.Handler.WhoCanAccess(c, params)
 }
 
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+// ExplainDecision operation middleware
+func (siw *ServerInterfaceWrapper) ExplainDecision(c *gin.Context) {
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
-}
+	var err error
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExplainDecisionParams
+
+	// ------------- Required query parameter "user" -------------
+
+	if paramValue := c.Query("user"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument user is required, but not found"), http.StatusBadRequest)
+		return
 	}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
+	err = runtime.BindQueryParameter("form", true, true, "user", c.Request.URL.Query(), &params.User)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	router.GET(options.BaseURL+"/health", wrapper.GetHealth)
-}
+	// ------------- Required query parameter "resource" -------------
 
-type GetHealthRequestObject struct {
-}
+	if paramValue := c.Query("resource"); paramValue != "" {
 
-type GetHealthResponseObject interface {
-	VisitGetHealthResponse(w http.ResponseWriter) error
-}
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument resource is required, but not found"), http.StatusBadRequest)
+		return
+	}
 
-type GetHealth200Response struct {
-}
+	err = runtime.BindQueryParameter("form", true, true, "resource", c.Request.URL.Query(), &params.Resource)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response GetHealth200Response) VisitGetHealthResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	// ------------- Required query parameter "action" -------------
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Health Check
-	// (GET /health)
-	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	if paramValue := c.Query("action"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument action is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "action", c.Request.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter action: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tenant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tenant", c.Request.URL.Query(), &params.Tenant)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tenant: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExplainDecision(c, params)
 }
 
-type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+// RemovePolicy operation middleware
+func (siw *ServerInterfaceWrapper) RemovePolicy(c *gin.Context) {
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemovePolicyParams
+
+	// ------------- Required query parameter "userGroup" -------------
+
+	if paramValue := c.Query("userGroup"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument userGroup is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "userGroup", c.Request.URL.Query(), &params.UserGroup)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userGroup: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "resourceGroup" -------------
+
+	if paramValue := c.Query("resourceGroup"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument resourceGroup is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "resourceGroup", c.Request.URL.Query(), &params.ResourceGroup)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resourceGroup: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "permission" -------------
+
+	if paramValue := c.Query("permission"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument permission is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "permission", c.Request.URL.Query(), &params.Permission)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter permission: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "effect" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "effect", c.Request.URL.Query(), &params.Effect)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter effect: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemovePolicy(c, params)
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+// ListPolicies operation middleware
+func (siw *ServerInterfaceWrapper) ListPolicies(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPoliciesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", c.Request.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prefix: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "contains", c.Request.URL.Query(), &params.Contains)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter contains: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListPolicies(c, params)
+}
+
+// AddPolicy operation middleware
+func (siw *ServerInterfaceWrapper) AddPolicy(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddPolicy(c)
+}
+
+// ListResourceGroups operation middleware
+func (siw *ServerInterfaceWrapper) ListResourceGroups(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListResourceGroupsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", c.Request.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prefix: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "contains", c.Request.URL.Query(), &params.Contains)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter contains: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListResourceGroups(c, params)
+}
+
+// CreateResourceGroup operation middleware
+func (siw *ServerInterfaceWrapper) CreateResourceGroup(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateResourceGroup(c)
+}
+
+// DeleteResourceGroup operation middleware
+func (siw *ServerInterfaceWrapper) DeleteResourceGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteResourceGroup(c, group)
+}
+
+// GetResourceGroup operation middleware
+func (siw *ServerInterfaceWrapper) GetResourceGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetResourceGroupParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", c.Request.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prefix: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "contains", c.Request.URL.Query(), &params.Contains)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter contains: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetResourceGroup(c, group, params)
+}
+
+// UpdateResourceGroup operation middleware
+func (siw *ServerInterfaceWrapper) UpdateResourceGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateResourceGroup(c, group)
+}
+
+// RemoveResourceFromGroup operation middleware
+func (siw *ServerInterfaceWrapper) RemoveResourceFromGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveResourceFromGroupParams
+
+	// ------------- Required query parameter "resource" -------------
+
+	if paramValue := c.Query("resource"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument resource is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "resource", c.Request.URL.Query(), &params.Resource)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveResourceFromGroup(c, group, params)
+}
+
+// AddResourceToGroup operation middleware
+func (siw *ServerInterfaceWrapper) AddResourceToGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddResourceToGroup(c, group)
+}
+
+// RemoveResource operation middleware
+func (siw *ServerInterfaceWrapper) RemoveResource(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveResourceParams
+
+	// ------------- Required query parameter "resource" -------------
+
+	if paramValue := c.Query("resource"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument resource is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "resource", c.Request.URL.Query(), &params.Resource)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveResource(c, params)
+}
+
+// GetGroupsForResource operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupsForResource(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGroupsForResourceParams
+
+	// ------------- Required query parameter "resource" -------------
+
+	if paramValue := c.Query("resource"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument resource is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "resource", c.Request.URL.Query(), &params.Resource)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter resource: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupsForResource(c, params)
+}
+
+// ListTenants operation middleware
+func (siw *ServerInterfaceWrapper) ListTenants(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTenants(c)
+}
+
+// CreateTenant operation middleware
+func (siw *ServerInterfaceWrapper) CreateTenant(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateTenant(c)
+}
+
+// DeleteTenant operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTenant(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "tenant" -------------
+	var tenant string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tenant", c.Param("tenant"), &tenant, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tenant: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteTenant(c, tenant)
+}
+
+// ListTimeBoundMemberships operation middleware
+func (siw *ServerInterfaceWrapper) ListTimeBoundMemberships(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTimeBoundMemberships(c)
+}
+
+// ListUserGroups operation middleware
+func (siw *ServerInterfaceWrapper) ListUserGroups(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUserGroupsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", c.Request.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prefix: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "contains", c.Request.URL.Query(), &params.Contains)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter contains: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListUserGroups(c, params)
+}
+
+// CreateUserGroup operation middleware
+func (siw *ServerInterfaceWrapper) CreateUserGroup(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateUserGroup(c)
+}
+
+// DeleteUserGroup operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUserGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUserGroup(c, group)
+}
+
+// GetUserGroup operation middleware
+func (siw *ServerInterfaceWrapper) GetUserGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserGroupParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", c.Request.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prefix: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "contains", c.Request.URL.Query(), &params.Contains)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter contains: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserGroup(c, group, params)
+}
+
+// UpdateUserGroup operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUserGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateUserGroup(c, group)
+}
+
+// AddUserGroupToGroup operation middleware
+func (siw *ServerInterfaceWrapper) AddUserGroupToGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddUserGroupToGroup(c, group)
+}
+
+// AddUserToGroup operation middleware
+func (siw *ServerInterfaceWrapper) AddUserToGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddUserToGroup(c, group)
+}
+
+// RemoveUserFromGroup operation middleware
+func (siw *ServerInterfaceWrapper) RemoveUserFromGroup(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "group" -------------
+	var group GroupName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group", c.Param("group"), &group, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter group: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "user" -------------
+	var user UserName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user", c.Param("user"), &user, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveUserFromGroup(c, group, user)
+}
+
+// RemoveUser operation middleware
+func (siw *ServerInterfaceWrapper) RemoveUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user" -------------
+	var user UserName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user", c.Param("user"), &user, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveUser(c, user)
+}
+
+// GetGroupsForUser operation middleware
+func (siw *ServerInterfaceWrapper) GetGroupsForUser(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user" -------------
+	var user UserName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user", c.Param("user"), &user, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetGroupsForUser(c, user)
+}
+
+// GetUserMemberships operation middleware
+func (siw *ServerInterfaceWrapper) GetUserMemberships(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user" -------------
+	var user UserName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user", c.Param("user"), &user, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserMemberships(c, user)
+}
+
+// GetEffectivePermissions operation middleware
+func (siw *ServerInterfaceWrapper) GetEffectivePermissions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user" -------------
+	var user string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user", c.Param("user"), &user, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEffectivePermissionsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetEffectivePermissions(c, user, params)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetHealth(c)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/auth/access", wrapper.WhoCanAccess)
+	router.GET(options.BaseURL+"/auth/explain", wrapper.ExplainDecision)
+	router.DELETE(options.BaseURL+"/auth/policies", wrapper.RemovePolicy)
+	router.GET(options.BaseURL+"/auth/policies", wrapper.ListPolicies)
+	router.POST(options.BaseURL+"/auth/policies", wrapper.AddPolicy)
+	router.GET(options.BaseURL+"/auth/resource-groups", wrapper.ListResourceGroups)
+	router.POST(options.BaseURL+"/auth/resource-groups", wrapper.CreateResourceGroup)
+	router.DELETE(options.BaseURL+"/auth/resource-groups/:group", wrapper.DeleteResourceGroup)
+	router.GET(options.BaseURL+"/auth/resource-groups/:group", wrapper.GetResourceGroup)
+	router.PATCH(options.BaseURL+"/auth/resource-groups/:group", wrapper.UpdateResourceGroup)
+	router.DELETE(options.BaseURL+"/auth/resource-groups/:group/resources", wrapper.RemoveResourceFromGroup)
+	router.POST(options.BaseURL+"/auth/resource-groups/:group/resources", wrapper.AddResourceToGroup)
+	router.DELETE(options.BaseURL+"/auth/resources", wrapper.RemoveResource)
+	router.GET(options.BaseURL+"/auth/resources/groups", wrapper.GetGroupsForResource)
+	router.GET(options.BaseURL+"/auth/tenants", wrapper.ListTenants)
+	router.POST(options.BaseURL+"/auth/tenants", wrapper.CreateTenant)
+	router.DELETE(options.BaseURL+"/auth/tenants/:tenant", wrapper.DeleteTenant)
+	router.GET(options.BaseURL+"/auth/time-bound-memberships", wrapper.ListTimeBoundMemberships)
+	router.GET(options.BaseURL+"/auth/user-groups", wrapper.ListUserGroups)
+	router.POST(options.BaseURL+"/auth/user-groups", wrapper.CreateUserGroup)
+	router.DELETE(options.BaseURL+"/auth/user-groups/:group", wrapper.DeleteUserGroup)
+	router.GET(options.BaseURL+"/auth/user-groups/:group", wrapper.GetUserGroup)
+	router.PATCH(options.BaseURL+"/auth/user-groups/:group", wrapper.UpdateUserGroup)
+	router.POST(options.BaseURL+"/auth/user-groups/:group/groups", wrapper.AddUserGroupToGroup)
+	router.POST(options.BaseURL+"/auth/user-groups/:group/users", wrapper.AddUserToGroup)
+	router.DELETE(options.BaseURL+"/auth/user-groups/:group/users/:user", wrapper.RemoveUserFromGroup)
+	router.DELETE(options.BaseURL+"/auth/users/:user", wrapper.RemoveUser)
+	router.GET(options.BaseURL+"/auth/users/:user/groups", wrapper.GetGroupsForUser)
+	router.GET(options.BaseURL+"/auth/users/:user/memberships", wrapper.GetUserMemberships)
+	router.GET(options.BaseURL+"/auth/users/:user/permissions", wrapper.GetEffectivePermissions)
+	router.GET(options.BaseURL+"/health", wrapper.GetHealth)
+}
+
+type BadRequestJSONResponse Error
+
+type ConflictJSONResponse Error
+
+type ForbiddenResponse struct {
+}
+
+type NotFoundJSONResponse Error
+
+type WhoCanAccessRequestObject struct {
+	Params WhoCanAccessParams
+}
+
+type WhoCanAccessResponseObject interface {
+	VisitWhoCanAccessResponse(w http.ResponseWriter) error
+}
+
+type WhoCanAccess200JSONResponse ResourceAccess
+
+func (response WhoCanAccess200JSONResponse) VisitWhoCanAccessResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WhoCanAccess403Response = ForbiddenResponse
+
+func (response WhoCanAccess403Response) VisitWhoCanAccessResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type ExplainDecisionRequestObject struct {
+	Params ExplainDecisionParams
+}
+
+type ExplainDecisionResponseObject interface {
+	VisitExplainDecisionResponse(w http.ResponseWriter) error
+}
+
+type ExplainDecision200JSONResponse Explanation
+
+func (response ExplainDecision200JSONResponse) VisitExplainDecisionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExplainDecision403Response = ForbiddenResponse
+
+func (response ExplainDecision403Response) VisitExplainDecisionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type RemovePolicyRequestObject struct {
+	Params RemovePolicyParams
+}
+
+type RemovePolicyResponseObject interface {
+	VisitRemovePolicyResponse(w http.ResponseWriter) error
+}
+
+type RemovePolicy204Response struct {
+}
+
+func (response RemovePolicy204Response) VisitRemovePolicyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemovePolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RemovePolicy400JSONResponse) VisitRemovePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemovePolicy403Response = ForbiddenResponse
+
+func (response RemovePolicy403Response) VisitRemovePolicyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type RemovePolicy409JSONResponse struct{ ConflictJSONResponse }
+
+func (response RemovePolicy409JSONResponse) VisitRemovePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPoliciesRequestObject struct {
+	Params ListPoliciesParams
+}
+
+type ListPoliciesResponseObject interface {
+	VisitListPoliciesResponse(w http.ResponseWriter) error
+}
+
+type ListPolicies200ResponseHeaders struct {
+	XNextCursor string
+}
+
+type ListPolicies200JSONResponse struct {
+	Body    []Policy
+	Headers ListPolicies200ResponseHeaders
+}
+
+func (response ListPolicies200JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListPolicies400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListPolicies400JSONResponse) VisitListPoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPolicies403Response = ForbiddenResponse
+
+func (response ListPolicies403Response) VisitListPoliciesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type AddPolicyRequestObject struct {
+	Body *AddPolicyJSONRequestBody
+}
+
+type AddPolicyResponseObject interface {
+	VisitAddPolicyResponse(w http.ResponseWriter) error
+}
+
+type AddPolicy204Response struct {
+}
+
+func (response AddPolicy204Response) VisitAddPolicyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AddPolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response AddPolicy400JSONResponse) VisitAddPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPolicy403Response = ForbiddenResponse
+
+func (response AddPolicy403Response) VisitAddPolicyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type AddPolicy404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response AddPolicy404JSONResponse) VisitAddPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddPolicy409JSONResponse struct{ ConflictJSONResponse }
+
+func (response AddPolicy409JSONResponse) VisitAddPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListResourceGroupsRequestObject struct {
+	Params ListResourceGroupsParams
+}
+
+type ListResourceGroupsResponseObject interface {
+	VisitListResourceGroupsResponse(w http.ResponseWriter) error
+}
+
+type ListResourceGroups200ResponseHeaders struct {
+	XNextCursor string
+}
+
+type ListResourceGroups200JSONResponse struct {
+	Body    []string
+	Headers ListResourceGroups200ResponseHeaders
+}
+
+func (response ListResourceGroups200JSONResponse) VisitListResourceGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListResourceGroups400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListResourceGroups400JSONResponse) VisitListResourceGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListResourceGroups403Response = ForbiddenResponse
+
+func (response ListResourceGroups403Response) VisitListResourceGroupsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type CreateResourceGroupRequestObject struct {
+	Body *CreateResourceGroupJSONRequestBody
+}
+
+type CreateResourceGroupResponseObject interface {
+	VisitCreateResourceGroupResponse(w http.ResponseWriter) error
+}
+
+type CreateResourceGroup204Response struct {
+}
+
+func (response CreateResourceGroup204Response) VisitCreateResourceGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type CreateResourceGroup400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateResourceGroup400JSONResponse) VisitCreateResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceGroup403Response = ForbiddenResponse
+
+func (response CreateResourceGroup403Response) VisitCreateResourceGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type CreateResourceGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response CreateResourceGroup404JSONResponse) VisitCreateResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response CreateResourceGroup409JSONResponse) VisitCreateResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteResourceGroupRequestObject struct {
+	Group GroupName `json:"group"`
+}
+
+type DeleteResourceGroupResponseObject interface {
+	VisitDeleteResourceGroupResponse(w http.ResponseWriter) error
+}
+
+type DeleteResourceGroup204Response struct {
+}
+
+func (response DeleteResourceGroup204Response) VisitDeleteResourceGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteResourceGroup403Response = ForbiddenResponse
+
+func (response DeleteResourceGroup403Response) VisitDeleteResourceGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteResourceGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteResourceGroup404JSONResponse) VisitDeleteResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteResourceGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response DeleteResourceGroup409JSONResponse) VisitDeleteResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceGroupRequestObject struct {
+	Group  GroupName `json:"group"`
+	Params GetResourceGroupParams
+}
+
+type GetResourceGroupResponseObject interface {
+	VisitGetResourceGroupResponse(w http.ResponseWriter) error
+}
+
+type GetResourceGroup200ResponseHeaders struct {
+	XNextCursor string
+}
+
+type GetResourceGroup200JSONResponse struct {
+	Body    Group
+	Headers GetResourceGroup200ResponseHeaders
+}
+
+func (response GetResourceGroup200JSONResponse) VisitGetResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetResourceGroup400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetResourceGroup400JSONResponse) VisitGetResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetResourceGroup403Response = ForbiddenResponse
+
+func (response GetResourceGroup403Response) VisitGetResourceGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetResourceGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetResourceGroup404JSONResponse) VisitGetResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateResourceGroupRequestObject struct {
+	Group GroupName `json:"group"`
+	Body  *UpdateResourceGroupJSONRequestBody
+}
+
+type UpdateResourceGroupResponseObject interface {
+	VisitUpdateResourceGroupResponse(w http.ResponseWriter) error
+}
+
+type UpdateResourceGroup204Response struct {
+}
+
+func (response UpdateResourceGroup204Response) VisitUpdateResourceGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UpdateResourceGroup400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateResourceGroup400JSONResponse) VisitUpdateResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateResourceGroup403Response = ForbiddenResponse
+
+func (response UpdateResourceGroup403Response) VisitUpdateResourceGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type UpdateResourceGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateResourceGroup404JSONResponse) VisitUpdateResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateResourceGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response UpdateResourceGroup409JSONResponse) VisitUpdateResourceGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveResourceFromGroupRequestObject struct {
+	Group  GroupName `json:"group"`
+	Params RemoveResourceFromGroupParams
+}
+
+type RemoveResourceFromGroupResponseObject interface {
+	VisitRemoveResourceFromGroupResponse(w http.ResponseWriter) error
+}
+
+type RemoveResourceFromGroup204Response struct {
+}
+
+func (response RemoveResourceFromGroup204Response) VisitRemoveResourceFromGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveResourceFromGroup403Response = ForbiddenResponse
+
+func (response RemoveResourceFromGroup403Response) VisitRemoveResourceFromGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type RemoveResourceFromGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RemoveResourceFromGroup404JSONResponse) VisitRemoveResourceFromGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveResourceFromGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response RemoveResourceFromGroup409JSONResponse) VisitRemoveResourceFromGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddResourceToGroupRequestObject struct {
+	Group GroupName `json:"group"`
+	Body  *AddResourceToGroupJSONRequestBody
+}
+
+type AddResourceToGroupResponseObject interface {
+	VisitAddResourceToGroupResponse(w http.ResponseWriter) error
+}
+
+type AddResourceToGroup204Response struct {
+}
+
+func (response AddResourceToGroup204Response) VisitAddResourceToGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AddResourceToGroup400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response AddResourceToGroup400JSONResponse) VisitAddResourceToGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddResourceToGroup403Response = ForbiddenResponse
+
+func (response AddResourceToGroup403Response) VisitAddResourceToGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type AddResourceToGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response AddResourceToGroup404JSONResponse) VisitAddResourceToGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddResourceToGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response AddResourceToGroup409JSONResponse) VisitAddResourceToGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveResourceRequestObject struct {
+	Params RemoveResourceParams
+}
+
+type RemoveResourceResponseObject interface {
+	VisitRemoveResourceResponse(w http.ResponseWriter) error
+}
+
+type RemoveResource204Response struct {
+}
+
+func (response RemoveResource204Response) VisitRemoveResourceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveResource403Response = ForbiddenResponse
+
+func (response RemoveResource403Response) VisitRemoveResourceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type RemoveResource409JSONResponse struct{ ConflictJSONResponse }
+
+func (response RemoveResource409JSONResponse) VisitRemoveResourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsForResourceRequestObject struct {
+	Params GetGroupsForResourceParams
+}
+
+type GetGroupsForResourceResponseObject interface {
+	VisitGetGroupsForResourceResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsForResource200JSONResponse []string
+
+func (response GetGroupsForResource200JSONResponse) VisitGetGroupsForResourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsForResource403Response = ForbiddenResponse
+
+func (response GetGroupsForResource403Response) VisitGetGroupsForResourceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type ListTenantsRequestObject struct {
+}
+
+type ListTenantsResponseObject interface {
+	VisitListTenantsResponse(w http.ResponseWriter) error
+}
+
+type ListTenants200JSONResponse []string
+
+func (response ListTenants200JSONResponse) VisitListTenantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTenants403Response = ForbiddenResponse
+
+func (response ListTenants403Response) VisitListTenantsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type CreateTenantRequestObject struct {
+	Body *CreateTenantJSONRequestBody
+}
+
+type CreateTenantResponseObject interface {
+	VisitCreateTenantResponse(w http.ResponseWriter) error
+}
+
+type CreateTenant204Response struct {
+}
+
+func (response CreateTenant204Response) VisitCreateTenantResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type CreateTenant400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateTenant400JSONResponse) VisitCreateTenantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTenant403Response = ForbiddenResponse
+
+func (response CreateTenant403Response) VisitCreateTenantResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteTenantRequestObject struct {
+	Tenant string `json:"tenant"`
+}
+
+type DeleteTenantResponseObject interface {
+	VisitDeleteTenantResponse(w http.ResponseWriter) error
+}
+
+type DeleteTenant204Response struct {
+}
+
+func (response DeleteTenant204Response) VisitDeleteTenantResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteTenant400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response DeleteTenant400JSONResponse) VisitDeleteTenantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTenant403Response = ForbiddenResponse
+
+func (response DeleteTenant403Response) VisitDeleteTenantResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteTenant404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteTenant404JSONResponse) VisitDeleteTenantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTenant409JSONResponse struct{ ConflictJSONResponse }
+
+func (response DeleteTenant409JSONResponse) VisitDeleteTenantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTimeBoundMembershipsRequestObject struct {
+}
+
+type ListTimeBoundMembershipsResponseObject interface {
+	VisitListTimeBoundMembershipsResponse(w http.ResponseWriter) error
+}
+
+type ListTimeBoundMemberships200JSONResponse []TimeBoundMembership
+
+func (response ListTimeBoundMemberships200JSONResponse) VisitListTimeBoundMembershipsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTimeBoundMemberships403Response = ForbiddenResponse
+
+func (response ListTimeBoundMemberships403Response) VisitListTimeBoundMembershipsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type ListUserGroupsRequestObject struct {
+	Params ListUserGroupsParams
+}
+
+type ListUserGroupsResponseObject interface {
+	VisitListUserGroupsResponse(w http.ResponseWriter) error
+}
+
+type ListUserGroups200ResponseHeaders struct {
+	XNextCursor string
+}
+
+type ListUserGroups200JSONResponse struct {
+	Body    []string
+	Headers ListUserGroups200ResponseHeaders
+}
+
+func (response ListUserGroups200JSONResponse) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListUserGroups400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListUserGroups400JSONResponse) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUserGroups403Response = ForbiddenResponse
+
+func (response ListUserGroups403Response) VisitListUserGroupsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type CreateUserGroupRequestObject struct {
+	Body *CreateUserGroupJSONRequestBody
+}
+
+type CreateUserGroupResponseObject interface {
+	VisitCreateUserGroupResponse(w http.ResponseWriter) error
+}
+
+type CreateUserGroup204Response struct {
+}
+
+func (response CreateUserGroup204Response) VisitCreateUserGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type CreateUserGroup400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateUserGroup400JSONResponse) VisitCreateUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserGroup403Response = ForbiddenResponse
+
+func (response CreateUserGroup403Response) VisitCreateUserGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type CreateUserGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response CreateUserGroup404JSONResponse) VisitCreateUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response CreateUserGroup409JSONResponse) VisitCreateUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserGroupRequestObject struct {
+	Group GroupName `json:"group"`
+}
+
+type DeleteUserGroupResponseObject interface {
+	VisitDeleteUserGroupResponse(w http.ResponseWriter) error
+}
+
+type DeleteUserGroup204Response struct {
+}
+
+func (response DeleteUserGroup204Response) VisitDeleteUserGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteUserGroup403Response = ForbiddenResponse
+
+func (response DeleteUserGroup403Response) VisitDeleteUserGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteUserGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteUserGroup404JSONResponse) VisitDeleteUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response DeleteUserGroup409JSONResponse) VisitDeleteUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserGroupRequestObject struct {
+	Group  GroupName `json:"group"`
+	Params GetUserGroupParams
+}
+
+type GetUserGroupResponseObject interface {
+	VisitGetUserGroupResponse(w http.ResponseWriter) error
+}
+
+type GetUserGroup200ResponseHeaders struct {
+	XNextCursor string
+}
+
+type GetUserGroup200JSONResponse struct {
+	Body    Group
+	Headers GetUserGroup200ResponseHeaders
+}
+
+func (response GetUserGroup200JSONResponse) VisitGetUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUserGroup400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetUserGroup400JSONResponse) VisitGetUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserGroup403Response = ForbiddenResponse
+
+func (response GetUserGroup403Response) VisitGetUserGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetUserGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetUserGroup404JSONResponse) VisitGetUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUserGroupRequestObject struct {
+	Group GroupName `json:"group"`
+	Body  *UpdateUserGroupJSONRequestBody
+}
+
+type UpdateUserGroupResponseObject interface {
+	VisitUpdateUserGroupResponse(w http.ResponseWriter) error
+}
+
+type UpdateUserGroup204Response struct {
+}
+
+func (response UpdateUserGroup204Response) VisitUpdateUserGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UpdateUserGroup400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateUserGroup400JSONResponse) VisitUpdateUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUserGroup403Response = ForbiddenResponse
+
+func (response UpdateUserGroup403Response) VisitUpdateUserGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type UpdateUserGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateUserGroup404JSONResponse) VisitUpdateUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUserGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response UpdateUserGroup409JSONResponse) VisitUpdateUserGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddUserGroupToGroupRequestObject struct {
+	Group GroupName `json:"group"`
+	Body  *AddUserGroupToGroupJSONRequestBody
+}
+
+type AddUserGroupToGroupResponseObject interface {
+	VisitAddUserGroupToGroupResponse(w http.ResponseWriter) error
+}
+
+type AddUserGroupToGroup204Response struct {
+}
+
+func (response AddUserGroupToGroup204Response) VisitAddUserGroupToGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AddUserGroupToGroup403Response = ForbiddenResponse
+
+func (response AddUserGroupToGroup403Response) VisitAddUserGroupToGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type AddUserGroupToGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response AddUserGroupToGroup404JSONResponse) VisitAddUserGroupToGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddUserGroupToGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response AddUserGroupToGroup409JSONResponse) VisitAddUserGroupToGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddUserToGroupRequestObject struct {
+	Group GroupName `json:"group"`
+	Body  *AddUserToGroupJSONRequestBody
+}
+
+type AddUserToGroupResponseObject interface {
+	VisitAddUserToGroupResponse(w http.ResponseWriter) error
+}
+
+type AddUserToGroup204Response struct {
+}
+
+func (response AddUserToGroup204Response) VisitAddUserToGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AddUserToGroup400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response AddUserToGroup400JSONResponse) VisitAddUserToGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddUserToGroup403Response = ForbiddenResponse
+
+func (response AddUserToGroup403Response) VisitAddUserToGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type AddUserToGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response AddUserToGroup404JSONResponse) VisitAddUserToGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddUserToGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response AddUserToGroup409JSONResponse) VisitAddUserToGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveUserFromGroupRequestObject struct {
+	Group GroupName `json:"group"`
+	User  UserName  `json:"user"`
+}
+
+type RemoveUserFromGroupResponseObject interface {
+	VisitRemoveUserFromGroupResponse(w http.ResponseWriter) error
+}
+
+type RemoveUserFromGroup204Response struct {
+}
+
+func (response RemoveUserFromGroup204Response) VisitRemoveUserFromGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveUserFromGroup403Response = ForbiddenResponse
+
+func (response RemoveUserFromGroup403Response) VisitRemoveUserFromGroupResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type RemoveUserFromGroup404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RemoveUserFromGroup404JSONResponse) VisitRemoveUserFromGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveUserFromGroup409JSONResponse struct{ ConflictJSONResponse }
+
+func (response RemoveUserFromGroup409JSONResponse) VisitRemoveUserFromGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveUserRequestObject struct {
+	User UserName `json:"user"`
+}
+
+type RemoveUserResponseObject interface {
+	VisitRemoveUserResponse(w http.ResponseWriter) error
+}
+
+type RemoveUser204Response struct {
+}
+
+func (response RemoveUser204Response) VisitRemoveUserResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveUser403Response = ForbiddenResponse
+
+func (response RemoveUser403Response) VisitRemoveUserResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type RemoveUser409JSONResponse struct{ ConflictJSONResponse }
+
+func (response RemoveUser409JSONResponse) VisitRemoveUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsForUserRequestObject struct {
+	User UserName `json:"user"`
+}
+
+type GetGroupsForUserResponseObject interface {
+	VisitGetGroupsForUserResponse(w http.ResponseWriter) error
+}
+
+type GetGroupsForUser200JSONResponse []string
+
+func (response GetGroupsForUser200JSONResponse) VisitGetGroupsForUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGroupsForUser403Response = ForbiddenResponse
+
+func (response GetGroupsForUser403Response) VisitGetGroupsForUserResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetUserMembershipsRequestObject struct {
+	User UserName `json:"user"`
+}
+
+type GetUserMembershipsResponseObject interface {
+	VisitGetUserMembershipsResponse(w http.ResponseWriter) error
+}
+
+type GetUserMemberships200JSONResponse NestedMembership
+
+func (response GetUserMemberships200JSONResponse) VisitGetUserMembershipsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserMemberships403Response = ForbiddenResponse
+
+func (response GetUserMemberships403Response) VisitGetUserMembershipsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetEffectivePermissionsRequestObject struct {
+	User   string `json:"user"`
+	Params GetEffectivePermissionsParams
+}
+
+type GetEffectivePermissionsResponseObject interface {
+	VisitGetEffectivePermissionsResponse(w http.ResponseWriter) error
+}
+
+type GetEffectivePermissions200JSONResponse PermissionReport
+
+func (response GetEffectivePermissions200JSONResponse) VisitGetEffectivePermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEffectivePermissions200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetEffectivePermissions200TextcsvResponse) VisitGetEffectivePermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetEffectivePermissions403Response = ForbiddenResponse
+
+func (response GetEffectivePermissions403Response) VisitGetEffectivePermissionsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200Response struct {
+}
+
+func (response GetHealth200Response) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Who can access a resource
+	// (GET /auth/access)
+	WhoCanAccess(ctx context.Context, request WhoCanAccessRequestObject) (WhoCanAccessResponseObject, error)
+	// Explain an authorization decision
+	// (GET /auth/explain)
+	ExplainDecision(ctx context.Context, request ExplainDecisionRequestObject) (ExplainDecisionResponseObject, error)
+	// Remove a policy
+	// (DELETE /auth/policies)
+	RemovePolicy(ctx context.Context, request RemovePolicyRequestObject) (RemovePolicyResponseObject, error)
+	// List policies
+	// (GET /auth/policies)
+	ListPolicies(ctx context.Context, request ListPoliciesRequestObject) (ListPoliciesResponseObject, error)
+	// Add a policy
+	// (POST /auth/policies)
+	AddPolicy(ctx context.Context, request AddPolicyRequestObject) (AddPolicyResponseObject, error)
+	// List resource groups
+	// (GET /auth/resource-groups)
+	ListResourceGroups(ctx context.Context, request ListResourceGroupsRequestObject) (ListResourceGroupsResponseObject, error)
+	// Create a resource group
+	// (POST /auth/resource-groups)
+	CreateResourceGroup(ctx context.Context, request CreateResourceGroupRequestObject) (CreateResourceGroupResponseObject, error)
+	// Delete a resource group
+	// (DELETE /auth/resource-groups/{group})
+	DeleteResourceGroup(ctx context.Context, request DeleteResourceGroupRequestObject) (DeleteResourceGroupResponseObject, error)
+	// Get a resource group and its resources
+	// (GET /auth/resource-groups/{group})
+	GetResourceGroup(ctx context.Context, request GetResourceGroupRequestObject) (GetResourceGroupResponseObject, error)
+	// Update the metadata of a resource group
+	// (PATCH /auth/resource-groups/{group})
+	UpdateResourceGroup(ctx context.Context, request UpdateResourceGroupRequestObject) (UpdateResourceGroupResponseObject, error)
+	// Remove a resource from a resource group
+	// (DELETE /auth/resource-groups/{group}/resources)
+	RemoveResourceFromGroup(ctx context.Context, request RemoveResourceFromGroupRequestObject) (RemoveResourceFromGroupResponseObject, error)
+	// Add a resource to a resource group
+	// (POST /auth/resource-groups/{group}/resources)
+	AddResourceToGroup(ctx context.Context, request AddResourceToGroupRequestObject) (AddResourceToGroupResponseObject, error)
+	// Remove a resource from all resource groups
+	// (DELETE /auth/resources)
+	RemoveResource(ctx context.Context, request RemoveResourceRequestObject) (RemoveResourceResponseObject, error)
+	// List the resource groups of a resource
+	// (GET /auth/resources/groups)
+	GetGroupsForResource(ctx context.Context, request GetGroupsForResourceRequestObject) (GetGroupsForResourceResponseObject, error)
+	// List tenants
+	// (GET /auth/tenants)
+	ListTenants(ctx context.Context, request ListTenantsRequestObject) (ListTenantsResponseObject, error)
+	// Create a tenant
+	// (POST /auth/tenants)
+	CreateTenant(ctx context.Context, request CreateTenantRequestObject) (CreateTenantResponseObject, error)
+	// Delete a tenant
+	// (DELETE /auth/tenants/{tenant})
+	DeleteTenant(ctx context.Context, request DeleteTenantRequestObject) (DeleteTenantResponseObject, error)
+	// List time-bound user group memberships
+	// (GET /auth/time-bound-memberships)
+	ListTimeBoundMemberships(ctx context.Context, request ListTimeBoundMembershipsRequestObject) (ListTimeBoundMembershipsResponseObject, error)
+	// List user groups
+	// (GET /auth/user-groups)
+	ListUserGroups(ctx context.Context, request ListUserGroupsRequestObject) (ListUserGroupsResponseObject, error)
+	// Create a user group
+	// (POST /auth/user-groups)
+	CreateUserGroup(ctx context.Context, request CreateUserGroupRequestObject) (CreateUserGroupResponseObject, error)
+	// Delete a user group
+	// (DELETE /auth/user-groups/{group})
+	DeleteUserGroup(ctx context.Context, request DeleteUserGroupRequestObject) (DeleteUserGroupResponseObject, error)
+	// Get a user group and its members
+	// (GET /auth/user-groups/{group})
+	GetUserGroup(ctx context.Context, request GetUserGroupRequestObject) (GetUserGroupResponseObject, error)
+	// Update the metadata of a user group
+	// (PATCH /auth/user-groups/{group})
+	UpdateUserGroup(ctx context.Context, request UpdateUserGroupRequestObject) (UpdateUserGroupResponseObject, error)
+	// Add a user group to a user group
+	// (POST /auth/user-groups/{group}/groups)
+	AddUserGroupToGroup(ctx context.Context, request AddUserGroupToGroupRequestObject) (AddUserGroupToGroupResponseObject, error)
+	// Add a user to a user group
+	// (POST /auth/user-groups/{group}/users)
+	AddUserToGroup(ctx context.Context, request AddUserToGroupRequestObject) (AddUserToGroupResponseObject, error)
+	// Remove a user from a user group
+	// (DELETE /auth/user-groups/{group}/users/{user})
+	RemoveUserFromGroup(ctx context.Context, request RemoveUserFromGroupRequestObject) (RemoveUserFromGroupResponseObject, error)
+	// Remove a user from all user groups
+	// (DELETE /auth/users/{user})
+	RemoveUser(ctx context.Context, request RemoveUserRequestObject) (RemoveUserResponseObject, error)
+	// List the user groups of a user
+	// (GET /auth/users/{user}/groups)
+	GetGroupsForUser(ctx context.Context, request GetGroupsForUserRequestObject) (GetGroupsForUserResponseObject, error)
+	// List the direct and inherited user groups of a user
+	// (GET /auth/users/{user}/memberships)
+	GetUserMemberships(ctx context.Context, request GetUserMembershipsRequestObject) (GetUserMembershipsResponseObject, error)
+	// Effective permissions of a user
+	// (GET /auth/users/{user}/permissions)
+	GetEffectivePermissions(ctx context.Context, request GetEffectivePermissionsRequestObject) (GetEffectivePermissionsResponseObject, error)
+	// Health Check
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// WhoCanAccess operation middleware
+func (sh *strictHandler) WhoCanAccess(ctx *gin.Context, params WhoCanAccessParams) {
+	var request WhoCanAccessRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.WhoCanAccess(ctx, request.(WhoCanAccessRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "WhoCanAccess")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(WhoCanAccessResponseObject); ok {
+		if err := validResponse.VisitWhoCanAccessResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExplainDecision operation middleware
+func (sh *strictHandler) ExplainDecision(ctx *gin.Context, params ExplainDecisionParams) {
+	var request ExplainDecisionRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ExplainDecision(ctx, request.(ExplainDecisionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExplainDecision")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ExplainDecisionResponseObject); ok {
+		if err := validResponse.VisitExplainDecisionResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemovePolicy operation middleware
+func (sh *strictHandler) RemovePolicy(ctx *gin.Context, params RemovePolicyParams) {
+	var request RemovePolicyRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemovePolicy(ctx, request.(RemovePolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemovePolicy")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RemovePolicyResponseObject); ok {
+		if err := validResponse.VisitRemovePolicyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPolicies operation middleware
+func (sh *strictHandler) ListPolicies(ctx *gin.Context, params ListPoliciesParams) {
+	var request ListPoliciesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPolicies(ctx, request.(ListPoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPolicies")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListPoliciesResponseObject); ok {
+		if err := validResponse.VisitListPoliciesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddPolicy operation middleware
+func (sh *strictHandler) AddPolicy(ctx *gin.Context) {
+	var request AddPolicyRequestObject
+
+	var body AddPolicyJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AddPolicy(ctx, request.(AddPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddPolicy")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(AddPolicyResponseObject); ok {
+		if err := validResponse.VisitAddPolicyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListResourceGroups operation middleware
+func (sh *strictHandler) ListResourceGroups(ctx *gin.Context, params ListResourceGroupsParams) {
+	var request ListResourceGroupsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListResourceGroups(ctx, request.(ListResourceGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListResourceGroups")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListResourceGroupsResponseObject); ok {
+		if err := validResponse.VisitListResourceGroupsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateResourceGroup operation middleware
+func (sh *strictHandler) CreateResourceGroup(ctx *gin.Context) {
+	var request CreateResourceGroupRequestObject
+
+	var body CreateResourceGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateResourceGroup(ctx, request.(CreateResourceGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateResourceGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateResourceGroupResponseObject); ok {
+		if err := validResponse.VisitCreateResourceGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteResourceGroup operation middleware
+func (sh *strictHandler) DeleteResourceGroup(ctx *gin.Context, group GroupName) {
+	var request DeleteResourceGroupRequestObject
+
+	request.Group = group
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteResourceGroup(ctx, request.(DeleteResourceGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteResourceGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteResourceGroupResponseObject); ok {
+		if err := validResponse.VisitDeleteResourceGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetResourceGroup operation middleware
+func (sh *strictHandler) GetResourceGroup(ctx *gin.Context, group GroupName, params GetResourceGroupParams) {
+	var request GetResourceGroupRequestObject
+
+	request.Group = group
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetResourceGroup(ctx, request.(GetResourceGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetResourceGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetResourceGroupResponseObject); ok {
+		if err := validResponse.VisitGetResourceGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateResourceGroup operation middleware
+func (sh *strictHandler) UpdateResourceGroup(ctx *gin.Context, group GroupName) {
+	var request UpdateResourceGroupRequestObject
+
+	request.Group = group
+
+	var body UpdateResourceGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateResourceGroup(ctx, request.(UpdateResourceGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateResourceGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UpdateResourceGroupResponseObject); ok {
+		if err := validResponse.VisitUpdateResourceGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveResourceFromGroup operation middleware
+func (sh *strictHandler) RemoveResourceFromGroup(ctx *gin.Context, group GroupName, params RemoveResourceFromGroupParams) {
+	var request RemoveResourceFromGroupRequestObject
+
+	request.Group = group
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveResourceFromGroup(ctx, request.(RemoveResourceFromGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveResourceFromGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RemoveResourceFromGroupResponseObject); ok {
+		if err := validResponse.VisitRemoveResourceFromGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddResourceToGroup operation middleware
+func (sh *strictHandler) AddResourceToGroup(ctx *gin.Context, group GroupName) {
+	var request AddResourceToGroupRequestObject
+
+	request.Group = group
+
+	var body AddResourceToGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AddResourceToGroup(ctx, request.(AddResourceToGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddResourceToGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(AddResourceToGroupResponseObject); ok {
+		if err := validResponse.VisitAddResourceToGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveResource operation middleware
+func (sh *strictHandler) RemoveResource(ctx *gin.Context, params RemoveResourceParams) {
+	var request RemoveResourceRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveResource(ctx, request.(RemoveResourceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveResource")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RemoveResourceResponseObject); ok {
+		if err := validResponse.VisitRemoveResourceResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetGroupsForResource operation middleware
+func (sh *strictHandler) GetGroupsForResource(ctx *gin.Context, params GetGroupsForResourceParams) {
+	var request GetGroupsForResourceRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroupsForResource(ctx, request.(GetGroupsForResourceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroupsForResource")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsForResourceResponseObject); ok {
+		if err := validResponse.VisitGetGroupsForResourceResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListTenants operation middleware
+func (sh *strictHandler) ListTenants(ctx *gin.Context) {
+	var request ListTenantsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTenants(ctx, request.(ListTenantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTenants")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListTenantsResponseObject); ok {
+		if err := validResponse.VisitListTenantsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateTenant operation middleware
+func (sh *strictHandler) CreateTenant(ctx *gin.Context) {
+	var request CreateTenantRequestObject
+
+	var body CreateTenantJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateTenant(ctx, request.(CreateTenantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateTenant")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateTenantResponseObject); ok {
+		if err := validResponse.VisitCreateTenantResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteTenant operation middleware
+func (sh *strictHandler) DeleteTenant(ctx *gin.Context, tenant string) {
+	var request DeleteTenantRequestObject
+
+	request.Tenant = tenant
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTenant(ctx, request.(DeleteTenantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTenant")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteTenantResponseObject); ok {
+		if err := validResponse.VisitDeleteTenantResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListTimeBoundMemberships operation middleware
+func (sh *strictHandler) ListTimeBoundMemberships(ctx *gin.Context) {
+	var request ListTimeBoundMembershipsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTimeBoundMemberships(ctx, request.(ListTimeBoundMembershipsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTimeBoundMemberships")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListTimeBoundMembershipsResponseObject); ok {
+		if err := validResponse.VisitListTimeBoundMembershipsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListUserGroups operation middleware
+func (sh *strictHandler) ListUserGroups(ctx *gin.Context, params ListUserGroupsParams) {
+	var request ListUserGroupsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUserGroups(ctx, request.(ListUserGroupsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUserGroups")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListUserGroupsResponseObject); ok {
+		if err := validResponse.VisitListUserGroupsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateUserGroup operation middleware
+func (sh *strictHandler) CreateUserGroup(ctx *gin.Context) {
+	var request CreateUserGroupRequestObject
+
+	var body CreateUserGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateUserGroup(ctx, request.(CreateUserGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateUserGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateUserGroupResponseObject); ok {
+		if err := validResponse.VisitCreateUserGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUserGroup operation middleware
+func (sh *strictHandler) DeleteUserGroup(ctx *gin.Context, group GroupName) {
+	var request DeleteUserGroupRequestObject
+
+	request.Group = group
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUserGroup(ctx, request.(DeleteUserGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUserGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteUserGroupResponseObject); ok {
+		if err := validResponse.VisitDeleteUserGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUserGroup operation middleware
+func (sh *strictHandler) GetUserGroup(ctx *gin.Context, group GroupName, params GetUserGroupParams) {
+	var request GetUserGroupRequestObject
+
+	request.Group = group
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserGroup(ctx, request.(GetUserGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUserGroupResponseObject); ok {
+		if err := validResponse.VisitGetUserGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateUserGroup operation middleware
+func (sh *strictHandler) UpdateUserGroup(ctx *gin.Context, group GroupName) {
+	var request UpdateUserGroupRequestObject
+
+	request.Group = group
+
+	var body UpdateUserGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateUserGroup(ctx, request.(UpdateUserGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateUserGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(UpdateUserGroupResponseObject); ok {
+		if err := validResponse.VisitUpdateUserGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddUserGroupToGroup operation middleware
+func (sh *strictHandler) AddUserGroupToGroup(ctx *gin.Context, group GroupName) {
+	var request AddUserGroupToGroupRequestObject
+
+	request.Group = group
+
+	var body AddUserGroupToGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AddUserGroupToGroup(ctx, request.(AddUserGroupToGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddUserGroupToGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(AddUserGroupToGroupResponseObject); ok {
+		if err := validResponse.VisitAddUserGroupToGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddUserToGroup operation middleware
+func (sh *strictHandler) AddUserToGroup(ctx *gin.Context, group GroupName) {
+	var request AddUserToGroupRequestObject
+
+	request.Group = group
+
+	var body AddUserToGroupJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AddUserToGroup(ctx, request.(AddUserToGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddUserToGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(AddUserToGroupResponseObject); ok {
+		if err := validResponse.VisitAddUserToGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveUserFromGroup operation middleware
+func (sh *strictHandler) RemoveUserFromGroup(ctx *gin.Context, group GroupName, user UserName) {
+	var request RemoveUserFromGroupRequestObject
+
+	request.Group = group
+	request.User = user
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveUserFromGroup(ctx, request.(RemoveUserFromGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveUserFromGroup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RemoveUserFromGroupResponseObject); ok {
+		if err := validResponse.VisitRemoveUserFromGroupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveUser operation middleware
+func (sh *strictHandler) RemoveUser(ctx *gin.Context, user UserName) {
+	var request RemoveUserRequestObject
+
+	request.User = user
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveUser(ctx, request.(RemoveUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveUser")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RemoveUserResponseObject); ok {
+		if err := validResponse.VisitRemoveUserResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetGroupsForUser operation middleware
+func (sh *strictHandler) GetGroupsForUser(ctx *gin.Context, user UserName) {
+	var request GetGroupsForUserRequestObject
+
+	request.User = user
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGroupsForUser(ctx, request.(GetGroupsForUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGroupsForUser")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetGroupsForUserResponseObject); ok {
+		if err := validResponse.VisitGetGroupsForUserResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUserMemberships operation middleware
+func (sh *strictHandler) GetUserMemberships(ctx *gin.Context, user UserName) {
+	var request GetUserMembershipsRequestObject
+
+	request.User = user
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserMemberships(ctx, request.(GetUserMembershipsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserMemberships")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUserMembershipsResponseObject); ok {
+		if err := validResponse.VisitGetUserMembershipsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEffectivePermissions operation middleware
+func (sh *strictHandler) GetEffectivePermissions(ctx *gin.Context, user string, params GetEffectivePermissionsParams) {
+	var request GetEffectivePermissionsRequestObject
+
+	request.User = user
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEffectivePermissions(ctx, request.(GetEffectivePermissionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEffectivePermissions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetEffectivePermissionsResponseObject); ok {
+		if err := validResponse.VisitGetEffectivePermissionsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
 // GetHealth operation middleware
@@ -140,9 +3744,71 @@ func (sh *strictHandler) GetHealth(ctx *gin.Context) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/ySNwWqDIRCEX0XmLFaam9dS2p7zBBK3dWmisrsNhB/fvRhP3xxmvjnA7bsjHTC2KyHh",
-	"XLNQce+tjM7NFB53EuXekBBDDBHTow9qeTASTiGGEzxGtqpL9FIpX62u+EO20AdJNu7tqyDhg+xzNzyE",
-	"dPSm9By+xrhQSC/Cw/bjmeRO4ljd1j4wp4f+3W5ZHkjYKvdW6fKLOef8DwAA//8W02Tf0gAAAA==",
+	"H4sIAAAAAAAC/+xcXXPbNpf+Kxjue7XDSN5tb9Z3Tupku9OmGTfZd2fqzA5MHoloSIAFQNtaj/77zjkA",
+	"QVCCJMqW0ybpTWKR+Dg433gOiIesUE2rJEhrsvOHrAJegqY/38K9fdVpozT+KsEUWrRWKJmdZ+45Uwtm",
+	"K2AS7i1r+RJyBk1rV0xJel5z457P2Du+BMOM5Sv856YGdleJGhhIqwUYxjUwXpZQMqWZhkbdQjnL8swU",
+	"FTQcCbCrFrLzzFgt5DJbr9d51nLNG7Ce3ldKWi6k2ab2F1mvWC2MNWG+O2ErxpnkDbDCdRRySVTf8roD",
+	"nFtg3z860Kssz7Bldp4V/ST7SMuznXxT0grZgXH8EcbirHxhQdMTZBazFbdMg+20hJIJy4Rj5/+8QJG8",
+	"8Lx3ktpJpyNgP5VvtOrat9ThwY3SclsNgyzxfZZnGv7ohIYyO7e6g/1j/iQaYbcX/jO/F03XMNk1N0CK",
+	"00tCLcLKc8brmokFU42w1ilAanE1zRHT0QiJw2fn/5b3NAlpYQmaiHqnYSHuj1YMY7kmAdFDopIG2kWX",
+	"e3uA6VdgVKcLGPF9YyDt2xzJ+w8G9B5xdgb0USOusbFplTRAVvWSl1fwRweGBIymAJL+5G1bi4IjV+e/",
+	"G2TtQzTsPzQssvPsX+aDq5m7t2Z+qbXSbqqxaPxETBgm5C2vRZmhXSm5qEXxGeb/pQVNA7LCz+l1o9XK",
+	"QmF7T2VA30LZqw/S+FrpG1GWIBPmz+saNK5JKss4a2CwhqLmt/C/vGyExFHeKvtadbJ8/pWSF2ClAkcV",
+	"3AtjSZ18Txz4oiypWST+VqsWtBVONZyrcJb4E8ilrWJbjHR00L7ffKePoZm6+R0Ki6u/KMveTnZOGYzk",
+	"yFlDvx0ToxXtnBTuW6HBXNCrhdINt9l5VnILL6xoINuaPM+ksi9hoTRM70KWeuSyqE9qSa80cAv7xTfS",
+	"iIfEGrxX2UtQnqk76QjfTyqNliL1crGAwopbeAe6EcZ4csa0NmArVSbJbFUtitUhS3jnWq3zQRUOkhx5",
+	"ZD9/mC25EDK2BOnG8OWE6fqGybHv25pLbpPM4cVOGfK6VncQM+5GqRo4uZuG26KC8t2RDJTA9c/C+Pgg",
+	"LDTmUOe3vgt293RwrfkheQwvSZPH82213RzYguTOge60teSL4+faEGTP9HyIvkGRvKxGM20tc8TjlDq8",
+	"6T3vWBEKsvryIpGLvVWWGbBsoTQjF2yYb81uyFH1TytesgYsL7nlmPNMc15+rJer7ZnRtbK7SoX5bOUn",
+	"y5n0VIkF6+Qnqe7kLDX6IU8lZAVaWCh/ptia2A34Fxh1JRikAvnfr5mSb66B6CmFhsL6MG1mLOZcLyff",
+	"kXLCyfrYDMRN79Q74d1uN8Ftn8AJ3HIh2YHjM/bLHe15uPvNlppLizkAa4P3NQkZpJz5sKKUigaT33aI",
+	"wpDnmeZxXguoy+P9/AbBvnM+TJ6m2QxKVImEhTnlOE6EQTuf4FL8vPFgqQUMIfQKWqUTQT8S82TvnYrQ",
+	"iXXucKqplCUf0ZFcSZD2mH4gWqZpgaMbRxtlcGODeY87PGqOKbpHIRi3zFbCMO/vHpH07ZsF3YyQzK2l",
+	"97/Hz9eOEqb9sXN/tJsmtjcBGogHHtGxW5SXQXAlLHhX4+ooUGabe5N/VmAr0Iz3HKNmBrdeJUjhcRTt",
+	"0lqDWIlLZEyWZyC7JoRgGlnGyZpfXJ7dv8CWL265RldmsEtM5oXvHj/7gYYK63F+aTvO4nBMSegxDltp",
+	"ANZybSn69IuKaD2atYdXQcR9iMaNHl9tTBG9iuz7I6EW6ENee12MBUe70K3dc2fbjuJkw61bq6YRorX6",
+	"joW5nbiemIb/+vWXt9mYrFe//rcn1S3qoiggFXNIcQaOmB2B0+cDHBVtFdQPt9+odSpn19m/XmeYrggb",
+	"PWdwC3qlJByVDww0HZkThNm23YzugIlByyj6I76GOmZ6Q+LEJMZlySRqqrcqgtcSu4QDWy9xxD5g2EQc",
+	"tQs4kMk/ZnPQHcv2CXvDSAtG2b2bK99WwrEORPxMudH3ooGXiA7ty08eAVUsd4YHl94lXx2NbyRRoDBF",
+	"ar0f2vLJEMYebGJjvjUlagtFjYWt8d2vFddQsktZtkpIixK6Be3ibXY2O5ud0RwtSN6K7Dz7bnY2+w7F",
+	"yG1F5M15Z6s5Dz5pCYkE5CcHRaPuuE0J2uWwO3GbE+GNF7dPlKsjzwlMRN3D2gtHzLLQYGHYpSAhM0aA",
+	"dzNsgDAqjaBHP1HDV6xAZ0GZCPhV42ZA9bjojyXFZ/WKS+9pxwWZ304JbafHCtZ21EgbbpL8SZxGIIMb",
+	"XgITcsb+KWylOos+XiHverP0+8S2rVcoBWIVjeRKWZZ/AsmExFdFoTrHu9QaXK+9NYOPGzD8v5+dnQwV",
+	"3giWCXj4gwsXcsc22asiav/3Z9/tmi7QPx/QcZzKdE3D9cppEiu4DAGJRYpi+ZISgIvOVtlH7OesCRAJ",
+	"E3KnOV1iKY9brOqEPJIWgeodDAfnxA7OcoLB4IpdEc4Mm2bHiLES1M4QsU0JhUCfcHpDu3RL/cFPMM3W",
+	"ji745M9vswH0+ttmH13JiQDghMGilSgt/o8aBJUkxRXWMIh7P9FovVaSDSVn3WO8ccJYQg2W8oex1l/R",
+	"Fvxdv0uaqPJvji9b79f7kw0YbeFOMJrHP/KJajMGQhIa+v22B3V9eijE6cvZYX2JCsWPUDHs8R+He4Ra",
+	"8Fgnnc7Em+sNDczTwQJz6hc3mFQPDqPgWq/QzwjNqAYt7IoMCf0FnhuAErM1ifssW8EqAMcDorMCO2Pv",
+	"+gHxvVGaoPZVFFDzDTg5jzBYmtCNNmOvRW1BGwdzkAccBmEe3h0PtR1LMMvsKdq2qhTThyZzd75jnR9s",
+	"6I/ATGjpT2dMGbM/ffNk//qkLeq2zw0Cjk6zZHl8mmp0eGfXxL79PDp6tV5/HqMb2RBqSLCClAW1yiRM",
+	"6KWy1VA7ugWCQ/A4AetkDcYMNkLYyYxdlKWrQHiIwgXoWgMvV66nCYclJAOtlZ6xHwIeQwy/Ba1F6RPR",
+	"4TmiHf0mPGc3ncXsEgeikz2jVMxlDCzsY9GOwubZiTPAtZRQDNaNJ0LodBa3hNduG9tFWYb45ZOVl6pc",
+	"nSwZCEWO9WZEWR/h4B2vP6N///5wj3AC5okB4aIs90SDkI/0XvPFMkBIe/bo1Ih0yaA6cFOAJF1WugSd",
+	"MyGLuqMH3h7ufFLaT2NSvnwYNe21rzZrw9+27z4E063zNCK/SITJr8p1byxtugens0JxUXiSR3bqSHpb",
+	"suvsujs7+65wmyT6G87P3TNs4Z5cZy4XKlQ7bJ9dlxyPBhcVq7gJAWTGnOiazlia/AbYnajLguvSsLga",
+	"33JrQUuzbUC0NrhK5PSn9smJE1eP9c9XI0l+1X7acS2GYHpkeKLLnj/Q/+vxjnKTn7g7MPHBE9wUNwFH",
+	"dwgPYgNqQa/6jGJboX6gObYV6kiZOlLLL0BCbsETJLRji+U+ArCVVt2yGvngdBBdbAXIvv22MN6A3ZTE",
+	"Nxkc9/klx5jkYesNeX5B0e9oGxip9BuwW/ocoLL+cTqAHqVdw6cWKOG2P/O0KYa25oX3TtErIojqVwPu",
+	"TkflZIyB5vRD2Qq0/9Kmk0XF5RLKGcNDJ3ToDoezonH4Nj1Rmgb8BG0Cd3aVt88RNBM1vhMFzY5GLr/O",
+	"qOnYRqLvj2r25y2eFkXng/YfRGh7hr/Wqnmc9x19FTMNmwxSHqGTf2lhBWwyCGehVTMpoD7J3SRz/ffx",
+	"HgiLyn3uzExb01dnVjEDywaoQmLZdTZH0OY6O6c8PiMXcp094K/1debDtJLQ98r7t+calnCPbaK33nct",
+	"u5oT3qLBwZ00DBjs/I/OgL7OqGXfS/Km/1LPuyH8GarlnFnNRY3P3AEd19DYzW3fjF0iPIT4zZJBbYgH",
+	"yE0q9NfCguZ1vZqxS/qkcWCELP3IXK7uKtDgeqMP1fA7fRqUhIF6fX2vntONJr6cebIbpQ8zv2ZwKJiB",
+	"VY/xnEd4yC/JMZ7ez9X1YWhim7vzLURuK/V3CMRrpZ+Nz38JiGr07AZqJZeGWXUa0GgHJjbpJIavo+8U",
+	"EU7w3rf581kbVf5PxLqwtGNwNp/oW3+aQS3dGRX6yhS3H/E5kTSqRi2usxgxW3R13R+i8bDaDqCzR9v2",
+	"wXZ0wlUOOMjq1GCf+/b9DmG8GYvQR9sf8ZgAP77fuVvqy7GdgTL3+wCcvOlvKIhZLKSxwMtdwOH7/tDH",
+	"XxwxdHR+XqQwjeOFYzIHvMb8wf0xGbjzyuE+1R+Quuh8Wr6l9KgUrpB/CNCLBD2R1yME72vLkALot0uc",
+	"6XM546sHbGw80w69fBzUJJzMeBHhtDsLdD9S6Q3GoO7os77x6YxkpS1xwvp0kWufJ0hMPCW2XWBIC3yK",
+	"l36iCDeMPZjZaJrdZo4dnrmqGr7QPL6mOjqD/3c99fHJanw8+CuqpUbL+ruOOqRDHzYOWv6FM6Lhc65v",
+	"o346aOw0p/zn1E3HCnSEDL/Aeuk+iUyulY4vI5hYMHWtXfhjP/YfjIdB3Bccd3xl+uOsuD6kul4la6yx",
+	"1P6ur06tr36I5f+N1VajdLGvq3rt+9arqs8dQk9YUY3c77dZTX1sTI2w69NX8H4ef1/mb7Pp0Szy9fQi",
+	"umCjb+uvgHnry2dkAHeqq732YtAqVkUN7iIMX9ViHtffmqwWn/owlyx9BU1/9trXqbU9Kn19AXWs+FNh",
+	"9RStDZ/En15pD521H1LKKeft+08Iwga0RwQI5W34JzCbo6I1cCR9p6o+u5bGVww+SUm/+sosyfQEujx/",
+	"wP/Whwu1yNb4GMtEQXyBZ1CIof78yf4NyqOdwOHEPFxZG+G9xwvss8nptGyv64jxJ0iHDzHzmEJ6mq1/",
+	"OrAYftOBpXB/7ukq4BsoppPXZxBNurKR3H+fsCSx/6LOjRvw9m5sN0UzQiuIk1SAUwZYuLcuwBtxMmlO",
+	"J0tPA848zPlnCXjjtr2D979sIrG0DH/HS2B1wSXTwIsq3zg/EH21SMmSv9xR2NNfTfEGbOJWQJNNqQue",
+	"6oYKf+1QPvnWk+g2s+e9rGHrGkbUVQv3do4Xn50/7FnrlrUFNqd2dMTKJ1/osGuGXWaCul4Br221z2v9",
+	"p2uRZvN4jb+CvnU+xA272qDQDcVeVVB8isjxM3xcr9fr/x8AWCin8NRiAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1,17 +1,29 @@
 package api
 
-import "context"
+import (
+	"context"
+
+	"github.com/EnclaveRunner/shareddeps/auth"
+)
 
 // ensure that we've conformed to the `ServerInterface` with
 // a compile-time check
 var _ StrictServerInterface = (*Server)(nil)
 
-type Server struct{}
+type Server struct {
+	authModule *auth.AuthModule
+}
 
 func NewServer() *Server {
 	return &Server{}
 }
 
+// NewAuthServer creates a server whose auth administration handlers are
+// backed by authModule.
+func NewAuthServer(authModule *auth.AuthModule) *Server {
+	return &Server{authModule: authModule}
+}
+
 // GetHealth implements StrictServerInterface.
 func (s *Server) GetHealth(
 	ctx context.Context,
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authPathPrefix is the path prefix of all auth administration operations.
+const authPathPrefix = "/auth/"
+
+// RegisterPublicHandlers registers all operations except the auth
+// administration endpoints. Those need an AuthModule and have to be registered
+// with RegisterAuthHandlers after the auth middleware has been added.
+func RegisterPublicHandlers(router gin.IRouter, si ServerInterface) {
+	registerFiltered(router, si, false)
+}
+
+// RegisterAuthHandlers registers the auth administration operations only.
+func RegisterAuthHandlers(router gin.IRouter, si ServerInterface) {
+	registerFiltered(router, si, true)
+}
+
+// registerFiltered registers the operations whose path is either an auth
+// administration path or not, depending on auth. The operations are collected
+// on a scratch engine first, so the split holds for every method the generated
+// code registers routes with.
+func registerFiltered(router gin.IRouter, si ServerInterface, auth bool) {
+	scratch := gin.New()
+	RegisterHandlers(scratch, si)
+
+	for _, route := range scratch.Routes() {
+		if strings.HasPrefix(route.Path, authPathPrefix) == auth {
+			router.Handle(route.Method, route.Path, route.HandlerFunc)
+		}
+	}
+}
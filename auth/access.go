@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"slices"
+	"time"
+)
+
+// ResourceAccess lists who is allowed to perform a method on a resource.
+type ResourceAccess struct {
+	Resource string
+	Method   string
+	Tenant   string
+	// Everyone is true if a policy for all users ("*") allows the request and
+	// none denies it. DeniedUserGroups and DeniedUsers are still excepted.
+	Everyone bool
+	// UserGroups contains all user groups that are granted access, including
+	// groups nested in them, and not denied.
+	UserGroups []string
+	// Users contains all users that are granted access, either directly or
+	// through one of UserGroups, and not denied.
+	Users []string
+	// DeniedUserGroups contains the user groups, including nested ones, a deny
+	// policy applies to. It contains "*" if everyone is denied.
+	DeniedUserGroups []string
+	// DeniedUsers contains the users a deny policy applies to, directly or
+	// through one of DeniedUserGroups.
+	DeniedUsers []string
+	// Policies contains the allow and deny policies that cover the request.
+	Policies []Policy
+}
+
+// WhoCanAccess returns all users and user groups that may perform method on
+// resource. It is the inverse of an enforcement: resource groups are matched
+// with KeyMatch2 patterns and wildcard resource groups, methods and subjects
+// are taken into account, and deny policies override allow policies. Resource
+// groups matching only through a UserPlaceholder pattern grant access to the
+// owner of the resource alone. Only policies that apply to all tenants are
+// taken into account, see WhoCanAccessInTenant.
+func (auth *AuthModule) WhoCanAccess(
+	resource, method string,
+) (ResourceAccess, error) {
+	return auth.WhoCanAccessInTenant("", resource, method)
+}
+
+// WhoCanAccessInTenant is like WhoCanAccess for requests made in tenant.
+func (auth *AuthModule) WhoCanAccessInTenant(
+	tenant, resource, method string,
+) (ResourceAccess, error) {
+	defer auth.rlock()()
+
+	resourceGroups, err := auth.resourceGroupsFor("", resource)
+	if err != nil {
+		return ResourceAccess{}, err
+	}
+	ownedGroups, err := auth.resourceOwners(resource)
+	if err != nil {
+		return ResourceAccess{}, err
+	}
+
+	policies, err := auth.listPolicies()
+	if err != nil {
+		return ResourceAccess{}, err
+	}
+	policies, err = auth.policiesInEffect(policies, time.Now())
+	if err != nil {
+		return ResourceAccess{}, err
+	}
+
+	access := ResourceAccess{
+		Resource:         resource,
+		Method:           method,
+		Tenant:           tenant,
+		UserGroups:       []string{},
+		Users:            []string{},
+		DeniedUserGroups: []string{},
+		DeniedUsers:      []string{},
+		Policies:         []Policy{},
+	}
+	everyoneDenied := false
+	var allowedGroups, allowedUsers []string
+	for _, policy := range policies {
+		owners, owned := ownedGroups[policy.ResourceGroup]
+		covered := policy.coversResourceGroups(resourceGroups)
+		if (!covered && !owned) || !policy.appliesIn(tenant) {
+			continue
+		}
+		if !auth.actionCovers(policy.Permission, method) {
+			continue
+		}
+
+		access.Policies = append(access.Policies, policy)
+
+		if !covered {
+			// Only the owners of the resource are covered, never whole groups
+			users, err := auth.ownersCovered(policy.UserGroup, owners)
+			if err != nil {
+				return ResourceAccess{}, err
+			}
+			if policy.Effect == EffectDeny {
+				access.DeniedUsers = append(access.DeniedUsers, users...)
+			} else {
+				allowedUsers = append(allowedUsers, users...)
+			}
+
+			continue
+		}
+
+		if policy.UserGroup == "*" {
+			if policy.Effect == EffectDeny {
+				everyoneDenied = true
+				access.DeniedUserGroups = append(access.DeniedUserGroups, "*")
+			} else {
+				access.Everyone = true
+			}
+
+			continue
+		}
+
+		groups, users, err := auth.expandSubject(policy.UserGroup)
+		if err != nil {
+			return ResourceAccess{}, err
+		}
+		if policy.Effect == EffectDeny {
+			access.DeniedUserGroups = append(access.DeniedUserGroups, groups...)
+			access.DeniedUsers = append(access.DeniedUsers, users...)
+		} else {
+			allowedGroups = append(allowedGroups, groups...)
+			allowedUsers = append(allowedUsers, users...)
+		}
+	}
+	access.Everyone = access.Everyone && !everyoneDenied
+
+	slices.Sort(access.DeniedUserGroups)
+	access.DeniedUserGroups = slices.Compact(access.DeniedUserGroups)
+	slices.Sort(access.DeniedUsers)
+	access.DeniedUsers = slices.Compact(access.DeniedUsers)
+
+	// Deny overrides allow for everyone, members of enclave_admin included
+	denied := func(name string, deniedNames []string) bool {
+		return everyoneDenied || slices.Contains(deniedNames, name)
+	}
+	access.UserGroups = filterNames(allowedGroups, func(group string) bool {
+		return !denied(group, access.DeniedUserGroups)
+	})
+	access.Users = filterNames(allowedUsers, func(user string) bool {
+		return !denied(user, access.DeniedUsers)
+	})
+
+	return access, nil
+}
+
+// expandSubject returns the user groups and users a policy subject stands
+// for: a single user, or a group with its nested groups and all their users.
+func (auth *AuthModule) expandSubject(
+	subject string,
+) ([]string, []string, error) {
+	isGroup, err := auth.userGroupManager.GroupExists(subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isGroup {
+		// Policies can also name a single user as subject
+		return nil, []string{subject}, nil
+	}
+
+	members, err := auth.membersInEffect(subject, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups := []string{subject}
+	var users []string
+	for _, member := range members {
+		// Nested groups inherit the access of their parent groups
+		isGroup, err := auth.userGroupManager.GroupExists(member)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isGroup {
+			groups = append(groups, member)
+		} else {
+			users = append(users, member)
+		}
+	}
+
+	return groups, users, nil
+}
+
+// ownersCovered returns the owners a policy subject applies to.
+func (auth *AuthModule) ownersCovered(
+	subject string,
+	owners []string,
+) ([]string, error) {
+	if subject == "*" {
+		return owners, nil
+	}
+
+	_, users, err := auth.expandSubject(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(users, func(user string) bool {
+		return !slices.Contains(owners, user)
+	}), nil
+}
+
+// filterNames returns the sorted, unique names keep returns true for.
+func filterNames(names []string, keep func(string) bool) []string {
+	kept := []string{}
+	for _, name := range names {
+		if keep(name) {
+			kept = append(kept, name)
+		}
+	}
+	slices.Sort(kept)
+
+	return slices.Compact(kept)
+}
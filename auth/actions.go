@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ActionSets maps action names to the HTTP methods, gRPC actions and other
+// action names they include. A policy whose permission is an action name
+// covers every request whose action is included in the set.
+type ActionSets map[string][]string
+
+// Action names of the sets of DefaultActionSets.
+const (
+	ActionRead  = "read"
+	ActionWrite = "write"
+	ActionAdmin = "admin"
+)
+
+// DefaultActionSets returns the read, write and admin action sets over the
+// HTTP methods, see WithActionSets.
+func DefaultActionSets() ActionSets {
+	return ActionSets{
+		ActionRead: {http.MethodGet, http.MethodHead, http.MethodOptions},
+		ActionWrite: {
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		},
+		ActionAdmin: {ActionRead, ActionWrite},
+	}
+}
+
+// grpcReadPrefixes are the method name prefixes of gRPC methods classified as
+// ActionRead if no rule of WithGRPCActions matches.
+var grpcReadPrefixes = []string{"Get", "List", "Watch", "Check", "Search"}
+
+// WithActionSets enables action sets, no sets are enabled by default. Pass
+// DefaultActionSets for read, write and admin. Once a set is enabled, stored
+// policies whose permission is its name cover every action in the set. Sets of
+// later options replace earlier sets with equal names.
+func WithActionSets(sets ActionSets) Option {
+	return func(auth *AuthModule) {
+		for name, actions := range sets {
+			auth.actionSets[name] = slices.Clone(actions)
+		}
+	}
+}
+
+// WithGRPCActions sets the rules GRPCAction classifies gRPC methods with. Keys
+// are full method names like "/pkg.Service/Method" or prefixes of them ending
+// with "*", like "/pkg.Service/*". The longest matching key wins.
+func WithGRPCActions(rules map[string]string) Option {
+	return func(auth *AuthModule) {
+		auth.grpcActions = rules
+	}
+}
+
+// GRPCAction classifies the gRPC method fullMethod into an action, so it can
+// be checked like an HTTP request, e.g. with
+// Check(ctx, fullMethod, GRPCAction(fullMethod)). Methods not matched by the
+// rules of WithGRPCActions are classified as ActionRead if their name starts
+// with Get, List, Watch, Check or Search and as ActionWrite otherwise.
+// Policies on ActionAdmin cover them once DefaultActionSets are enabled.
+func (auth *AuthModule) GRPCAction(fullMethod string) string {
+	action, matched := "", -1
+	for pattern, ruleAction := range auth.grpcActions {
+		prefix, isPrefix := strings.CutSuffix(pattern, "*")
+		switch {
+		case !isPrefix && pattern == fullMethod:
+			return ruleAction
+		case isPrefix && strings.HasPrefix(fullMethod, prefix) &&
+			len(prefix) > matched:
+			action, matched = ruleAction, len(prefix)
+		}
+	}
+	if matched >= 0 {
+		return action
+	}
+
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range grpcReadPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return ActionRead
+		}
+	}
+
+	return ActionWrite
+}
+
+// ActionSets returns the action sets of the module with nested action names
+// resolved: each set contains all methods and action names it includes.
+func (auth *AuthModule) ActionSets() ActionSets {
+	sets := make(ActionSets, len(auth.actionSets))
+	for name, actions := range auth.actionSets {
+		sets[name] = slices.Clone(actions)
+	}
+
+	return sets
+}
+
+// resolve returns the action sets with nested action names resolved.
+func (sets ActionSets) resolve() ActionSets {
+	resolved := make(ActionSets, len(sets))
+	for name := range sets {
+		resolved[name] = sets.expand(name)
+	}
+
+	return resolved
+}
+
+// expand returns the sorted actions included in the set name, following
+// nested action names. Cycles between sets are ignored.
+func (sets ActionSets) expand(name string) []string {
+	visited := map[string]bool{name: true}
+	pending := slices.Clone(sets[name])
+	var actions []string
+	for len(pending) > 0 {
+		action := pending[0]
+		pending = pending[1:]
+		if visited[action] {
+			continue
+		}
+		visited[action] = true
+
+		actions = append(actions, action)
+		pending = append(pending, sets[action]...)
+	}
+	slices.Sort(actions)
+
+	return actions
+}
+
+// actionCovers reports whether a policy with permission covers action. It
+// mirrors the action part of the casbin matcher.
+func (auth *AuthModule) actionCovers(permission, action string) bool {
+	return permission == "*" || permission == action ||
+		slices.Contains(auth.actionSets[permission], action)
+}
+
+// inActionSetFunc returns the casbin matcher function
+// inActionSet(r.act, p.act), which reports whether the action of a request is
+// included in the action set named by the permission of a policy. sets have to
+// be resolved.
+func inActionSetFunc(sets ActionSets) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		//nolint:mnd // Arguments of inActionSet
+		if len(args) != 2 {
+			return false, nil
+		}
+		action, _ := args[0].(string)
+		permission, _ := args[1].(string)
+
+		return slices.Contains(sets[permission], action), nil
+	}
+}
@@ -3,13 +3,23 @@ package auth_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/EnclaveRunner/shareddeps/auth"
+	"github.com/EnclaveRunner/shareddeps/config"
+	"github.com/casbin/casbin/v3/model"
+	"github.com/casbin/casbin/v3/persist"
 	fileadapter "github.com/casbin/casbin/v3/persist/file-adapter"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -405,10 +415,24 @@ func TestRemoveResourceGroup(t *testing.T) {
 
 			authModule := setupTestAuth(t)
 
-			// Create a test resource group for the removal test
+			// Create a test resource group for the removal test, with a user
+			// group of the same name whose policy must survive
 			if tc.groupName == "testRemoveResourceGroup" {
 				err := authModule.CreateResourceGroup("testRemoveResourceGroup")
 				require.NoError(t, err)
+				err = authModule.CreateUserGroup("testRemoveResourceGroup")
+				require.NoError(t, err)
+				require.NoError(t, authModule.CreateResourceGroup("runs"))
+				require.NoError(t, authModule.AddPolicy(
+					"testRemoveResourceGroup",
+					"runs",
+					"GET",
+				))
+				require.NoError(t, authModule.AddPolicy(
+					"*",
+					"testRemoveResourceGroup",
+					"GET",
+				))
 			}
 
 			err := authModule.RemoveResourceGroup(tc.groupName)
@@ -424,6 +448,22 @@ func TestRemoveResourceGroup(t *testing.T) {
 				exists, err := authModule.ResourceGroupExists(tc.groupName)
 				assert.NoError(t, err)
 				assert.False(t, exists)
+
+				// Only the policies on the resource group are removed
+				policies, err := authModule.ListPolicies()
+				assert.NoError(t, err)
+				assert.NotContains(t, policies, auth.Policy{
+					UserGroup:     "*",
+					ResourceGroup: tc.groupName,
+					Permission:    "GET",
+					Effect:        auth.EffectAllow,
+				})
+				assert.Contains(t, policies, auth.Policy{
+					UserGroup:     tc.groupName,
+					ResourceGroup: "runs",
+					Permission:    "GET",
+					Effect:        auth.EffectAllow,
+				})
 			}
 		})
 	}
@@ -658,19 +698,27 @@ func TestGetUserGroups(t *testing.T) {
 	require.NoError(t, err)
 	err = authModule.CreateUserGroup("testGroup2")
 	require.NoError(t, err)
+	err = authModule.AddUserToGroup("bob", "testGroup2")
+	require.NoError(t, err)
+	err = authModule.AddUserToGroup("alice", "testGroup2")
+	require.NoError(t, err)
 
+	// Placeholder rows of empty groups are hidden
 	groups, err := authModule.GetUserGroups()
 	assert.NoError(t, err)
-	assert.NotEmpty(t, groups)
+	assert.Equal(t, []auth.UserGroup{
+		{UserName: "alice", GroupName: "testGroup2"},
+		{UserName: "bob", GroupName: "testGroup2"},
+	}, groups)
 
-	// Should contain at least our test groups and enclaveAdmin
-	groupNames := make([]string, len(groups))
-	for i, group := range groups {
-		groupNames[i] = group.GroupName
-	}
-	assert.Contains(t, groupNames, "testGroup1")
-	assert.Contains(t, groupNames, "testGroup2")
-	assert.Contains(t, groupNames, enclaveAdminGroup)
+	// Should contain our test groups and enclaveAdmin, even without members
+	page, err := authModule.ListUserGroupsPage(auth.ListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]string{enclaveAdminGroup, "testGroup1", "testGroup2"},
+		page.Items,
+	)
 }
 
 func TestGetResourceGroups(t *testing.T) {
@@ -683,18 +731,24 @@ func TestGetResourceGroups(t *testing.T) {
 	require.NoError(t, err)
 	err = authModule.CreateResourceGroup("testResourceGroup2")
 	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/runs", "testResourceGroup1")
+	require.NoError(t, err)
 
+	// Placeholder rows of empty groups are hidden
 	groups, err := authModule.GetResourceGroups()
 	assert.NoError(t, err)
-	assert.NotEmpty(t, groups)
+	assert.Equal(t, []auth.ResourceGroup{
+		{ResourceName: "/v1/runs", GroupName: "testResourceGroup1"},
+	}, groups)
 
-	// Should contain at least our test groups
-	groupNames := make([]string, len(groups))
-	for i, group := range groups {
-		groupNames[i] = group.GroupName
-	}
-	assert.Contains(t, groupNames, "testResourceGroup1")
-	assert.Contains(t, groupNames, "testResourceGroup2")
+	// Should contain our test groups, even without resources
+	page, err := authModule.ListResourceGroupsPage(auth.ListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]string{"testResourceGroup1", "testResourceGroup2"},
+		page.Items,
+	)
 }
 
 func TestGetUserGroup(t *testing.T) {
@@ -894,61 +948,3450 @@ func TestMiddleware_ResourcePatternMatching(t *testing.T) {
 	}
 }
 
-// Benchmark tests
-func BenchmarkInitAuth(b *testing.B) {
-	tempDir := b.TempDir()
-	tempFile := filepath.Join(tempDir, "bench_policy.csv")
+func TestCheck(t *testing.T) {
+	t.Parallel()
 
-	for range b.N {
-		adapter := fileadapter.NewAdapter(tempFile)
-		auth.NewModule(adapter)
+	authModule := setupTestAuth(t)
+
+	err := authModule.CreateUserGroup("artifact_readers")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("artifacts")
+	require.NoError(t, err)
+	err = authModule.AddUserToGroup("test-user", "artifact_readers")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("artifact:123", "artifacts")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/artifacts/:id", "artifacts")
+	require.NoError(t, err)
+	err = authModule.AddPolicy("artifact_readers", "artifacts", "read")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		user     string
+		resource string
+		action   string
+		want     bool
+	}{
+		{
+			name:     "allows granted object",
+			user:     "test-user",
+			resource: "artifact:123",
+			action:   "read",
+			want:     true,
+		},
+		{
+			name:     "allows granted path pattern",
+			user:     "test-user",
+			resource: "/v1/artifacts/456",
+			action:   "read",
+			want:     true,
+		},
+		{
+			name:     "denies other object with same prefix",
+			user:     "test-user",
+			resource: "artifact:456",
+			action:   "read",
+			want:     false,
+		},
+		{
+			name:     "denies action not covered by policy",
+			user:     "test-user",
+			resource: "artifact:123",
+			action:   "write",
+			want:     false,
+		},
+		{
+			name:     "denies unauthenticated user",
+			user:     auth.UnauthenticatedUser,
+			resource: "artifact:123",
+			action:   "read",
+			want:     false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := auth.SetAuthenticatedUser(t.Context(), tc.user)
+
+			allowed, err := authModule.Check(ctx, tc.resource, tc.action)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, allowed)
+
+			err = authModule.Require(ctx, tc.resource, tc.action)
+			if tc.want {
+				assert.NoError(t, err)
+			} else {
+				var errForbidden *auth.ForbiddenError
+				assert.ErrorAs(t, err, &errForbidden)
+				assert.Equal(t, tc.user, errForbidden.User)
+				assert.Equal(t, tc.resource, errForbidden.Resource)
+				assert.Equal(t, tc.action, errForbidden.Action)
+			}
+		})
 	}
 }
 
-func BenchmarkAddUserToGroup(b *testing.B) {
-	tempDir := b.TempDir()
-	tempFile := filepath.Join(tempDir, "bench_policy.csv")
+func TestBatchCheck(t *testing.T) {
+	t.Parallel()
 
-	// Create the file
-	file, err := os.Create(tempFile)
-	require.NoError(b, err)
-	err = file.Close()
-	require.NoError(b, err)
+	authModule := setupTestAuth(t)
 
-	adapter := fileadapter.NewAdapter(tempFile)
-	authModule := auth.NewModule(adapter)
-	err = authModule.CreateUserGroup("benchGroup")
-	require.NoError(b, err)
+	err := authModule.CreateUserGroup("run_readers")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("runs")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("public")
+	require.NoError(t, err)
+	err = authModule.AddUserToGroup("test-user", "run_readers")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/runs/:id", "runs")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/status", "public")
+	require.NoError(t, err)
+	err = authModule.AddPolicy("run_readers", "runs", http.MethodGet)
+	require.NoError(t, err)
+	err = authModule.AddPolicy("*", "public", "*")
+	require.NoError(t, err)
 
-	b.ResetTimer()
-	for range b.N {
-		err = authModule.AddUserToGroup("benchUser", "benchGroup")
-		require.NoError(b, err)
-		err = authModule.RemoveUser("benchUser")
-		require.NoError(b, err)
+	requests := []auth.AccessRequest{
+		{Resource: "/v1/runs/1", Action: http.MethodGet},
+		{Resource: "/v1/runs/1", Action: http.MethodDelete},
+		{Resource: "/v1/runs/2", Action: http.MethodGet},
+		{Resource: "/v1/teams/1", Action: http.MethodGet},
+		{Resource: "/v1/status", Action: http.MethodPost},
+	}
+
+	allowed, err := authModule.BatchCheck("test-user", requests)
+	require.NoError(t, err)
+	assert.Equal(t, []auth.AccessRequest{
+		{Resource: "/v1/runs/1", Action: http.MethodGet},
+		{Resource: "/v1/runs/2", Action: http.MethodGet},
+		{Resource: "/v1/status", Action: http.MethodPost},
+	}, allowed)
+
+	// BatchCheck has to agree with the enforcer for every user
+	for _, user := range []string{"test-user", "other-user", enclaveAdminGroup} {
+		allowed, err := authModule.BatchCheck(user, requests)
+		require.NoError(t, err)
+
+		ctx := auth.SetAuthenticatedUser(t.Context(), user)
+		for _, request := range requests {
+			want, err := authModule.Check(ctx, request.Resource, request.Action)
+			require.NoError(t, err)
+			assert.Equal(
+				t,
+				want,
+				slices.Contains(allowed, request),
+				"user %s, request %v",
+				user,
+				request,
+			)
+		}
 	}
+
+	allowed, err = authModule.BatchCheck("test-user", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, allowed)
 }
 
-func BenchmarkAddPolicy(b *testing.B) {
-	tempDir := b.TempDir()
-	tempFile := filepath.Join(tempDir, "bench_policy.csv")
+func TestExplain(t *testing.T) {
+	t.Parallel()
 
-	// Create the file
-	file, err := os.Create(tempFile)
-	require.NoError(b, err)
-	err = file.Close()
-	require.NoError(b, err)
+	authModule := setupTestAuth(t)
 
-	adapter := fileadapter.NewAdapter(tempFile)
-	authModule := auth.NewModule(adapter)
-	err = authModule.CreateUserGroup("benchUserGroup")
-	require.NoError(b, err)
-	err = authModule.CreateResourceGroup("benchResourceGroup")
-	require.NoError(b, err)
+	err := authModule.CreateUserGroup("run_readers")
+	require.NoError(t, err)
+	err = authModule.CreateUserGroup("team_readers")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("runs")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("teams")
+	require.NoError(t, err)
+	err = authModule.AddUserToGroup("test-user", "run_readers")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/runs/:id", "runs")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/teams/:id", "teams")
+	require.NoError(t, err)
+	err = authModule.AddPolicy("run_readers", "runs", http.MethodGet)
+	require.NoError(t, err)
+	err = authModule.AddPolicy("run_readers", "teams", http.MethodGet)
+	require.NoError(t, err)
+	err = authModule.AddPolicy("team_readers", "runs", http.MethodDelete)
+	require.NoError(t, err)
 
-	b.ResetTimer()
-	for range b.N {
-		err = authModule.AddPolicy("benchUserGroup", "benchResourceGroup", "GET")
+	t.Run("allowed request", func(t *testing.T) {
+		t.Parallel()
+
+		explanation, err := authModule.Explain(
+			"test-user",
+			"/v1/runs/42",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		assert.True(t, explanation.Allowed)
+		assert.Equal(t, []string{"run_readers"}, explanation.UserGroups)
+		assert.Equal(t, []string{"runs"}, explanation.ResourceGroups)
+		assert.Equal(t, &auth.Policy{
+			UserGroup:     "run_readers",
+			ResourceGroup: "runs",
+			Permission:    http.MethodGet,
+			Effect:        auth.EffectAllow,
+		}, explanation.MatchedPolicy)
+		assert.Empty(t, explanation.NearMisses)
+	})
+
+	t.Run("denied request", func(t *testing.T) {
+		t.Parallel()
+
+		explanation, err := authModule.Explain(
+			"test-user",
+			"/v1/runs/42",
+			http.MethodDelete,
+		)
+		require.NoError(t, err)
+		assert.False(t, explanation.Allowed)
+		assert.Nil(t, explanation.MatchedPolicy)
+		assert.ElementsMatch(t, []auth.NearMiss{
+			{
+				Policy: auth.Policy{
+					UserGroup:     "run_readers",
+					ResourceGroup: "runs",
+					Permission:    http.MethodGet,
+					Effect:        auth.EffectAllow,
+				},
+				Mismatch: auth.PolicyFieldPermission,
+			},
+			{
+				Policy: auth.Policy{
+					UserGroup:     "team_readers",
+					ResourceGroup: "runs",
+					Permission:    http.MethodDelete,
+					Effect:        auth.EffectAllow,
+				},
+				Mismatch: auth.PolicyFieldUserGroup,
+			},
+			{
+				Policy: auth.Policy{
+					UserGroup:     enclaveAdminGroup,
+					ResourceGroup: "*",
+					Permission:    "*",
+					Effect:        auth.EffectAllow,
+				},
+				Mismatch: auth.PolicyFieldUserGroup,
+			},
+		}, explanation.NearMisses)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		explanation, err := authModule.Explain(
+			"unknown-user",
+			"/v1/unknown",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		assert.False(t, explanation.Allowed)
+		assert.Empty(t, explanation.UserGroups)
+		assert.Empty(t, explanation.ResourceGroups)
+	})
+}
+
+func TestEffectivePermissions(t *testing.T) {
+	t.Parallel()
+
+	authModule := setupTestAuth(t)
+
+	err := authModule.CreateUserGroup("run_readers")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("runs")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("teams")
+	require.NoError(t, err)
+	err = authModule.AddUserToGroup("test-user", "run_readers")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/runs", "runs")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/runs/:id", "runs")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/teams/:id", "teams")
+	require.NoError(t, err)
+	err = authModule.AddPolicy("run_readers", "runs", http.MethodGet)
+	require.NoError(t, err)
+	err = authModule.AddPolicy("*", "teams", "*")
+	require.NoError(t, err)
+
+	report, err := authModule.EffectivePermissions("test-user")
+	require.NoError(t, err)
+	assert.Equal(t, "test-user", report.User)
+	assert.Equal(t, []auth.EffectivePermission{
+		{
+			Resource: "/v1/runs",
+			Method:   http.MethodGet,
+			Policy: auth.Policy{
+				UserGroup:     "run_readers",
+				ResourceGroup: "runs",
+				Permission:    http.MethodGet,
+				Effect:        auth.EffectAllow,
+			},
+		},
+		{
+			Resource: "/v1/runs/:id",
+			Method:   http.MethodGet,
+			Policy: auth.Policy{
+				UserGroup:     "run_readers",
+				ResourceGroup: "runs",
+				Permission:    http.MethodGet,
+				Effect:        auth.EffectAllow,
+			},
+		},
+		{
+			Resource: "/v1/teams/:id",
+			Method:   "*",
+			Policy: auth.Policy{
+				UserGroup:     "*",
+				ResourceGroup: "teams",
+				Permission:    "*",
+				Effect:        auth.EffectAllow,
+			},
+		},
+	}, report.Permissions)
+
+	var csvReport strings.Builder
+	err = report.WriteCSV(&csvReport)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"user,resource,method,userGroup,resourceGroup,permission,effect\n"+
+			"test-user,/v1/runs,GET,run_readers,runs,GET,allow\n"+
+			"test-user,/v1/runs/:id,GET,run_readers,runs,GET,allow\n"+
+			"test-user,/v1/teams/:id,*,*,teams,*,allow\n",
+		csvReport.String(),
+	)
+
+	var jsonReport strings.Builder
+	err = report.WriteJSON(&jsonReport)
+	require.NoError(t, err)
+	assert.Contains(
+		t,
+		jsonReport.String(),
+		`{"resource":"/v1/teams/:id","method":"*","policy":`+
+			`{"userGroup":"*","resourceGroup":"teams","permission":"*",`+
+			`"effect":"allow"}}`,
+	)
+
+	// Admins get a wildcard grant through the enclave_admin policy
+	err = authModule.AddUserToGroup("admin-user", enclaveAdminGroup)
+	require.NoError(t, err)
+
+	report, err = authModule.EffectivePermissions("admin-user")
+	require.NoError(t, err)
+	assert.Contains(t, report.Permissions, auth.EffectivePermission{
+		Resource: "*",
+		Method:   "*",
+		Policy: auth.Policy{
+			UserGroup:     enclaveAdminGroup,
+			ResourceGroup: "*",
+			Permission:    "*",
+			Effect:        auth.EffectAllow,
+		},
+	})
+}
+
+func TestWhoCanAccess(t *testing.T) {
+	t.Parallel()
+
+	authModule := setupTestAuth(t)
+
+	err := authModule.CreateUserGroup("run_readers")
+	require.NoError(t, err)
+	err = authModule.CreateUserGroup("run_admins")
+	require.NoError(t, err)
+	err = authModule.CreateUserGroup("team_readers")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("runs")
+	require.NoError(t, err)
+	err = authModule.CreateResourceGroup("teams")
+	require.NoError(t, err)
+	err = authModule.AddUserToGroup("reader", "run_readers")
+	require.NoError(t, err)
+	err = authModule.AddUserToGroup("admin", "run_admins", "run_readers")
+	require.NoError(t, err)
+	err = authModule.AddUserToGroup("team-reader", "team_readers")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/runs/:id", "runs")
+	require.NoError(t, err)
+	err = authModule.AddResourceToGroup("/v1/teams/:id", "teams")
+	require.NoError(t, err)
+	err = authModule.AddPolicy("run_readers", "runs", http.MethodGet)
+	require.NoError(t, err)
+	err = authModule.AddPolicy("run_admins", "runs", "*")
+	require.NoError(t, err)
+	err = authModule.AddPolicy("team_readers", "teams", http.MethodGet)
+	require.NoError(t, err)
+
+	access, err := authModule.WhoCanAccess("/v1/runs/42", http.MethodGet)
+	require.NoError(t, err)
+	assert.False(t, access.Everyone)
+	assert.Equal(
+		t,
+		[]string{enclaveAdminGroup, "run_admins", "run_readers"},
+		access.UserGroups,
+	)
+	assert.Equal(t, []string{"admin", "reader"}, access.Users)
+	assert.Len(t, access.Policies, 3)
+
+	access, err = authModule.WhoCanAccess("/v1/runs/42", http.MethodDelete)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]string{enclaveAdminGroup, "run_admins"},
+		access.UserGroups,
+	)
+	assert.Equal(t, []string{"admin"}, access.Users)
+
+	// Every returned user has to be allowed by the enforcer
+	for _, user := range access.Users {
+		ctx := auth.SetAuthenticatedUser(t.Context(), user)
+		allowed, err := authModule.Check(ctx, "/v1/runs/42", http.MethodDelete)
+		require.NoError(t, err)
+		assert.True(t, allowed, user)
+	}
+
+	err = authModule.AddPolicy("*", "teams", http.MethodGet)
+	require.NoError(t, err)
+
+	access, err = authModule.WhoCanAccess("/v1/teams/1", http.MethodGet)
+	require.NoError(t, err)
+	assert.True(t, access.Everyone)
+	assert.Equal(
+		t,
+		[]string{enclaveAdminGroup, "team_readers"},
+		access.UserGroups,
+	)
+	assert.Equal(t, []string{"team-reader"}, access.Users)
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	t.Parallel()
+
+	authModule := setupTestAuth(t)
+
+	err := authModule.AddUserToGroup("admin-user", enclaveAdminGroup)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(authModule.AdminMiddleware())
+	router.GET("/auth/explain", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	testCases := []struct {
+		name       string
+		user       string
+		wantStatus int
+	}{
+		{
+			name:       "allows admin",
+			user:       "admin-user",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "denies regular user",
+			user:       "test-user",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "denies unauthenticated user",
+			user:       auth.UnauthenticatedUser,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(
+				t.Context(),
+				http.MethodGet,
+				"/auth/explain",
+				http.NoBody,
+			)
+			req = req.WithContext(auth.SetAuthenticatedUser(req.Context(), tc.user))
+			res := httptest.NewRecorder()
+
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.wantStatus, res.Code)
+		})
+	}
+}
+
+func TestApplyDocument(t *testing.T) {
+	t.Parallel()
+
+	document, err := auth.ParsePolicyDocument(strings.NewReader(`
+userGroups:
+  runners: [alice, bob]
+resourceGroups:
+  runs: [/runs/*]
+policies:
+  - userGroup: runners
+    resourceGroup: runs
+    permission: GET
+`))
+	require.NoError(t, err)
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+
+		diff, err := authModule.ApplyDocument(
+			document,
+			auth.ApplyOptions{DryRun: true},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"runners"}, diff.Added.UserGroups)
+		assert.Equal(t, []auth.Membership{
+			{Group: "runners", Member: "alice"},
+			{Group: "runners", Member: "bob"},
+		}, diff.Added.UserMemberships)
+		assert.Equal(t, []string{"runs"}, diff.Added.ResourceGroups)
+		assert.Equal(t, []auth.Policy{
+			{
+				UserGroup:     "runners",
+				ResourceGroup: "runs",
+				Permission:    "GET",
+				Effect:        auth.EffectAllow,
+			},
+		}, diff.Added.Policies)
+		assert.Empty(t, diff.Removed)
+
+		exists, err := authModule.UserGroupExists("runners")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("apply is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+
+		diff, err := authModule.ApplyDocument(document, auth.ApplyOptions{})
+		require.NoError(t, err)
+		assert.False(t, diff.IsEmpty())
+
+		allowed, err := authModule.Check(
+			auth.SetAuthenticatedUser(context.Background(), "alice"),
+			"/runs/1",
+			"GET",
+		)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		diff, err = authModule.DiffDocument(document, true)
+		require.NoError(t, err)
+		assert.True(t, diff.IsEmpty())
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.CreateUserGroup("runners"))
+		require.NoError(t, authModule.AddUserToGroup("mallory", "runners"))
+		require.NoError(t, authModule.CreateUserGroup("legacy"))
+		require.NoError(t, authModule.AddPolicy("legacy", "*", "GET"))
+		require.NoError(t, authModule.AddUserToGroup("root", enclaveAdminGroup))
+
+		diff, err := authModule.DiffDocument(document, false)
+		require.NoError(t, err)
+		assert.Empty(t, diff.Removed)
+
+		diff, err = authModule.ApplyDocument(
+			document,
+			auth.ApplyOptions{Prune: true},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"legacy"}, diff.Removed.UserGroups)
+		assert.Equal(t, []auth.Membership{
+			{Group: "runners", Member: "mallory"},
+		}, diff.Removed.UserMemberships)
+		assert.Equal(t, []auth.Policy{
+			{
+				UserGroup:     "legacy",
+				ResourceGroup: "*",
+				Permission:    "GET",
+				Effect:        auth.EffectAllow,
+			},
+		}, diff.Removed.Policies)
+
+		members, err := authModule.GetUserGroup("runners")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"alice", "bob"}, members)
+
+		// enclave_admin is not part of the document and stays untouched
+		isAdmin, err := authModule.IsAdmin("root")
+		require.NoError(t, err)
+		assert.True(t, isAdmin)
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.Contains(t, policies, auth.Policy{
+			UserGroup:     enclaveAdminGroup,
+			ResourceGroup: "*",
+			Permission:    "*",
+			Effect:        auth.EffectAllow,
+		})
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+
+		_, err := authModule.ApplyDocument(auth.PolicyDocument{
+			UserGroups: map[string][]string{"runners": {"alice"}},
+			Policies: []auth.Policy{
+				{UserGroup: "runners", ResourceGroup: "missing", Permission: "GET"},
+			},
+		}, auth.ApplyOptions{})
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+
+		_, err = authModule.ApplyDocument(auth.PolicyDocument{
+			UserGroups: map[string][]string{"runners": {nullUser}},
+		}, auth.ApplyOptions{})
+		require.ErrorIs(t, err, &auth.ConflictError{})
+
+		exists, err := authModule.UserGroupExists("runners")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		_, err = auth.ParsePolicyDocument(strings.NewReader(`{"groups": {}}`))
+		require.Error(t, err)
+	})
+
+	t.Run("rollback on failed save", func(t *testing.T) {
+		t.Parallel()
+
+		policyFile := filepath.Join(t.TempDir(), "policy", "test_policy.csv")
+		require.NoError(t, os.MkdirAll(filepath.Dir(policyFile), 0o755))
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o644))
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile))
+		require.NoError(t, os.RemoveAll(filepath.Dir(policyFile)))
+
+		_, err := authModule.ApplyDocument(document, auth.ApplyOptions{})
+		require.ErrorIs(t, err, &auth.CasbinError{})
+
+		exists, err := authModule.UserGroupExists("runners")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.Len(t, policies, 1)
+	})
+}
+
+func TestWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	policyFile := filepath.Join(t.TempDir(), "test_policy.csv")
+	require.NoError(t, os.WriteFile(policyFile, nil, 0o644))
+	defaults := auth.WithDefaults(config.AuthConfig{
+		UserGroups: []config.GroupConfig{
+			{Name: "runners", Members: []string{"alice"}},
+		},
+		ResourceGroups: []config.GroupConfig{
+			{Name: "runs", Members: []string{"/runs/*"}},
+		},
+		Policies: []config.PolicyConfig{
+			{UserGroup: "runners", ResourceGroup: "runs", Permission: "GET"},
+		},
+	})
+
+	authModule := auth.NewModule(fileadapter.NewAdapter(policyFile), defaults)
+	members, err := authModule.GetUserGroup("runners")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, members)
+
+	// Runtime changes survive a restart with the same defaults
+	require.NoError(t, authModule.AddUserToGroup("bob", "runners"))
+	require.NoError(t, authModule.AddPolicy("runners", "runs", "POST"))
+
+	authModule = auth.NewModule(fileadapter.NewAdapter(policyFile), defaults)
+	members, err = authModule.GetUserGroup("runners")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"alice", "bob"}, members)
+
+	policies, err := authModule.ListPolicies()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []auth.Policy{
+		{
+			UserGroup:     enclaveAdminGroup,
+			ResourceGroup: "*",
+			Permission:    "*",
+			Effect:        auth.EffectAllow,
+		},
+		{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    "GET",
+			Effect:        auth.EffectAllow,
+		},
+		{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    "POST",
+			Effect:        auth.EffectAllow,
+		},
+	}, policies)
+}
+
+// countingAdapter counts full saves of the wrapped adapter.
+type countingAdapter struct {
+	persist.Adapter
+
+	saves int
+}
+
+func (a *countingAdapter) SavePolicy(model model.Model) error {
+	a.saves++
+
+	return a.Adapter.SavePolicy(model)
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (auth.AuthModule, *countingAdapter, string) {
+		t.Helper()
+
+		policyFile := filepath.Join(t.TempDir(), "policy", "test_policy.csv")
+		require.NoError(t, os.MkdirAll(filepath.Dir(policyFile), 0o755))
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o644))
+		adapter := &countingAdapter{Adapter: fileadapter.NewAdapter(policyFile)}
+		authModule := auth.NewModule(adapter)
+		adapter.saves = 0
+
+		return authModule, adapter, policyFile
+	}
+
+	populate := func(tx *auth.Tx) error {
+		err := tx.CreateUserGroup("runners")
+		if err != nil {
+			return err
+		}
+		for i := range 50 {
+			err = tx.AddUserToGroup("user"+strconv.Itoa(i), "runners")
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.CreateResourceGroup("runs")
+		if err != nil {
+			return err
+		}
+		err = tx.AddResourceToGroup("/runs/*", "runs")
+		if err != nil {
+			return err
+		}
+		for _, method := range []string{"GET", "POST", "DELETE"} {
+			err = tx.AddPolicy("runners", "runs", method)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	t.Run("persists once", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, adapter, policyFile := setup(t)
+
+		require.NoError(t, authModule.Update(populate))
+		assert.Equal(t, 1, adapter.saves)
+
+		reloaded := auth.NewModule(fileadapter.NewAdapter(policyFile))
+		members, err := reloaded.GetUserGroup("runners")
+		require.NoError(t, err)
+		assert.Len(t, members, 50)
+		policies, err := reloaded.ListPolicies()
+		require.NoError(t, err)
+		assert.Len(t, policies, 4)
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, adapter, _ := setup(t)
+		require.NoError(t, authModule.CreateUserGroup("existing"))
+		require.NoError(t, authModule.AddUserToGroup("alice", "existing"))
+		adapter.saves = 0
+
+		err := authModule.Update(func(tx *auth.Tx) error {
+			err := populate(tx)
+			if err != nil {
+				return err
+			}
+			err = tx.RemoveUserGroup("existing")
+			if err != nil {
+				return err
+			}
+
+			// Staged changes are visible inside the transaction
+			exists, err := tx.UserGroupExists("runners")
+			require.NoError(t, err)
+			assert.True(t, exists)
+
+			return tx.AddPolicy("runners", "missing", "GET")
+		})
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+		assert.Zero(t, adapter.saves)
+
+		exists, err := authModule.UserGroupExists("runners")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		members, err := authModule.GetUserGroup("existing")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice"}, members)
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.Len(t, policies, 1)
+	})
+
+	t.Run("rolls back on failed save", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _, policyFile := setup(t)
+		require.NoError(t, os.RemoveAll(filepath.Dir(policyFile)))
+
+		err := authModule.Update(populate)
+		require.ErrorIs(t, err, &auth.CasbinError{})
+
+		exists, err := authModule.UserGroupExists("runners")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		allowed, err := authModule.Check(
+			auth.SetAuthenticatedUser(context.Background(), "user1"),
+			"/runs/1",
+			"GET",
+		)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("nested updates join the transaction", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, adapter, _ := setup(t)
+
+		err := authModule.Update(func(tx *auth.Tx) error {
+			return tx.Update(populate)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, adapter.saves)
+	})
+}
+
+// memoryAdapter is an adapter with auto save support. It counts full saves
+// and can fail writes of a single rule.
+type memoryAdapter struct {
+	rules  map[string][]string
+	saves  int
+	failOn string
+}
+
+func newMemoryAdapter(lines ...[]string) *memoryAdapter {
+	a := &memoryAdapter{rules: map[string][]string{}}
+	for _, line := range lines {
+		a.rules[strings.Join(line, ",")] = line
+	}
+
+	return a
+}
+
+func (a *memoryAdapter) LoadPolicy(m model.Model) error {
+	for _, line := range a.rules {
+		err := persist.LoadPolicyArray(line, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *memoryAdapter) SavePolicy(m model.Model) error {
+	a.saves++
+	a.rules = map[string][]string{}
+	for _, sec := range []string{"p", "g"} {
+		for ptype, assertion := range m[sec] {
+			for _, rule := range assertion.Policy {
+				line := append([]string{ptype}, rule...)
+				a.rules[strings.Join(line, ",")] = line
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *memoryAdapter) AddPolicy(sec, ptype string, rule []string) error {
+	line := append([]string{ptype}, rule...)
+	key := strings.Join(line, ",")
+	if key == a.failOn {
+		return errors.New("write failed")
+	}
+	a.rules[key] = line
+
+	return nil
+}
+
+func (a *memoryAdapter) RemovePolicy(sec, ptype string, rule []string) error {
+	delete(a.rules, strings.Join(append([]string{ptype}, rule...), ","))
+
+	return nil
+}
+
+func (a *memoryAdapter) RemoveFilteredPolicy(
+	sec, ptype string,
+	fieldIndex int,
+	fieldValues ...string,
+) error {
+	return errors.New("not implemented")
+}
+
+func TestIncrementalPersistence(t *testing.T) {
+	t.Parallel()
+
+	adapter := newMemoryAdapter()
+	authModule := auth.NewModule(adapter)
+	adapter.saves = 0
+
+	require.NoError(t, authModule.CreateUserGroup("runners"))
+	require.NoError(t, authModule.AddUserToGroup("alice", "runners"))
+	require.NoError(t, authModule.AddPolicy("runners", "*", "GET"))
+	require.NoError(t, authModule.RemovePolicy("runners", "*", "GET"))
+
+	assert.Zero(t, adapter.saves)
+	assert.Contains(t, adapter.rules, "g,alice,runners")
+	assert.NotContains(t, adapter.rules, "p,runners,*,GET,allow")
+
+	// A failed write rolls back the enforcer and the rules written before
+	adapter.failOn = "p,runners,*,POST,allow"
+	err := authModule.Update(func(tx *auth.Tx) error {
+		err := tx.AddUserToGroup("bob", "runners")
+		if err != nil {
+			return err
+		}
+
+		return tx.AddPolicy("runners", "*", "POST")
+	})
+	require.ErrorIs(t, err, &auth.CasbinError{})
+	assert.NotContains(t, adapter.rules, "g,bob,runners")
+	members, err := authModule.GetUserGroup("runners")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, members)
+
+	// The persisted rules load into the same state
+	reloaded := auth.NewModule(adapter)
+	members, err = reloaded.GetUserGroup("runners")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, members)
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	authModule := setupTestAuth(t)
+	require.NoError(t, authModule.CreateResourceGroup("runs"))
+	require.NoError(t, authModule.AddResourceToGroup("/runs/:id", "runs"))
+
+	const workers = 16
+	var wg sync.WaitGroup
+	for worker := range workers {
+		user := "user" + strconv.Itoa(worker)
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+
+		// Writers race on the same group and policy
+		wg.Go(func() {
+			for range 20 {
+				assert.NoError(t, authModule.CreateUserGroup("runners"))
+				assert.NoError(t, authModule.AddUserToGroup(user, "runners"))
+				assert.NoError(t, authModule.AddPolicy("runners", "runs", "GET"))
+				err := authModule.Update(func(tx *auth.Tx) error {
+					err := tx.CreateUserGroup(user + "-group")
+					if err != nil {
+						return err
+					}
+
+					return tx.AddUserToGroup(user, user+"-group")
+				})
+				assert.NoError(t, err)
+				assert.NoError(t, authModule.RemoveUserGroup(user+"-group"))
+			}
+		})
+
+		// Readers run alongside
+		wg.Go(func() {
+			for range 20 {
+				_, err := authModule.Check(ctx, "/runs/1", "GET")
+				assert.NoError(t, err)
+				_, err = authModule.Explain(user, "/runs/1", "GET")
+				assert.NoError(t, err)
+				_, err = authModule.EffectivePermissions(user)
+				assert.NoError(t, err)
+				_, err = authModule.WhoCanAccess("/runs/1", "GET")
+				assert.NoError(t, err)
+				_, err = authModule.GetUserGroups()
+				assert.NoError(t, err)
+				_, err = authModule.ListPolicies()
+				assert.NoError(t, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	groups, err := authModule.ListUserGroupsPage(
+		auth.ListOptions{Prefix: "runners"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"runners"}, groups.Items)
+
+	members, err := authModule.GetUserGroup("runners")
+	require.NoError(t, err)
+	assert.Len(t, members, workers)
+
+	policies, err := authModule.ListPolicies()
+	require.NoError(t, err)
+	assert.Len(t, policies, 2)
+
+	for worker := range workers {
+		ctx := auth.SetAuthenticatedUser(
+			context.Background(),
+			"user"+strconv.Itoa(worker),
+		)
+		allowed, err := authModule.Check(ctx, "/runs/1", "GET")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	t.Parallel()
+
+	// newReplicas creates two modules sharing one policy file, each with the
+	// watcher returned by newWatcher.
+	newReplicas := func(
+		t *testing.T,
+		newWatcher func() persist.Watcher,
+	) (auth.AuthModule, auth.AuthModule) {
+		t.Helper()
+
+		policyFile := filepath.Join(t.TempDir(), "policies.csv")
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o600))
+
+		replicas := make([]auth.AuthModule, 2)
+		for i := range replicas {
+			watcher := newWatcher()
+			t.Cleanup(watcher.Close)
+			replicas[i] = auth.NewModule(
+				fileadapter.NewAdapter(policyFile),
+				auth.WithWatcher(watcher),
+			)
+		}
+
+		return replicas[0], replicas[1]
+	}
+
+	allowed := func(module auth.AuthModule, user, resource string) bool {
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+		ok, err := module.Check(ctx, resource, "GET")
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	// change grants alice access to runs on one replica
+	change := func(t *testing.T, module auth.AuthModule) {
+		t.Helper()
+
+		err := module.Update(func(tx *auth.Tx) error {
+			return errors.Join(
+				tx.CreateUserGroup("runners"),
+				tx.AddUserToGroup("alice", "runners"),
+				tx.CreateResourceGroup("runs"),
+				tx.AddResourceToGroup("/runs/:id", "runs"),
+				tx.AddPolicy("runners", "runs", "GET"),
+			)
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("in-process broadcaster", func(t *testing.T) {
+		t.Parallel()
+
+		broadcaster := auth.NewMemoryBroadcaster()
+		first, second := newReplicas(t, broadcaster.NewWatcher)
+		require.False(t, allowed(second, "alice", "/runs/1"))
+
+		change(t, first)
+		assert.True(t, allowed(second, "alice", "/runs/1"))
+
+		// Changes flow in both directions, peers do not lose earlier ones
+		require.NoError(t, second.RemoveUserFromGroup("alice", "runners"))
+		assert.False(t, allowed(first, "alice", "/runs/1"))
+		require.NoError(t, first.AddUserToGroup("bob", "runners"))
+		assert.True(t, allowed(second, "bob", "/runs/1"))
+	})
+
+	t.Run("file watcher", func(t *testing.T) {
+		t.Parallel()
+
+		watchFile := filepath.Join(t.TempDir(), "policies.watch")
+		first, second := newReplicas(t, func() persist.Watcher {
+			watcher, err := auth.NewFileWatcher(watchFile)
+			require.NoError(t, err)
+
+			return watcher
+		})
+		require.False(t, allowed(second, "alice", "/runs/1"))
+
+		change(t, first)
+		assert.Eventually(t, func() bool {
+			return allowed(second, "alice", "/runs/1")
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("no notification without changes", func(t *testing.T) {
+		t.Parallel()
+
+		notified := 0
+		broadcaster := auth.NewMemoryBroadcaster()
+		peer := broadcaster.NewWatcher()
+		require.NoError(t, peer.SetUpdateCallback(func(string) { notified++ }))
+
+		first, _ := newReplicas(t, broadcaster.NewWatcher)
+		notified = 0
+		require.NoError(t, first.CreateUserGroup("runners"))
+		require.NoError(t, first.CreateUserGroup("runners"))
+		assert.Error(t, first.AddUserToGroup("alice", "missing"))
+		assert.Equal(t, 1, notified)
+	})
+}
+
+func TestPolicyFileReload(t *testing.T) {
+	t.Parallel()
+
+	const baseline = "p, enclave_admin, *, *, allow\n" +
+		"g, null_user, enclave_admin\n"
+	const granted = baseline + `p, runners, runs, GET, allow
+g, null_user, runners
+g, alice, runners
+g2, null_resource, runs
+g2, /runs/:id, runs
+`
+
+	setup := func(
+		t *testing.T,
+		opts ...auth.Option,
+	) (auth.AuthModule, string) {
+		t.Helper()
+
+		policyFile := filepath.Join(t.TempDir(), "policies.csv")
+		require.NoError(t, os.WriteFile(policyFile, []byte(baseline), 0o600))
+		authModule := auth.NewModule(
+			fileadapter.NewAdapter(policyFile),
+			append(opts, auth.WithPolicyFileReload(policyFile))...,
+		)
+		t.Cleanup(func() { assert.NoError(t, authModule.Close()) })
+
+		return authModule, policyFile
+	}
+
+	allowed := func(module auth.AuthModule, user string) bool {
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+		ok, err := module.Check(ctx, "/runs/1", "GET")
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	t.Run("reloads edited file", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, policyFile := setup(t)
+		require.NoError(t, os.WriteFile(policyFile, []byte(granted), 0o600))
+
+		assert.Eventually(t, func() bool {
+			return allowed(authModule, "alice")
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// Removing lines revokes access again
+		require.NoError(t, os.WriteFile(policyFile, []byte(baseline), 0o600))
+		assert.Eventually(t, func() bool {
+			return !allowed(authModule, "alice")
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("own saves are no changes", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		require.NoError(t, authModule.CreateUserGroup("runners"))
+
+		diff, err := authModule.ReloadPolicy()
+		require.NoError(t, err)
+		assert.True(t, diff.IsEmpty())
+	})
+
+	t.Run("copies close the module", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, policyFile := setup(t)
+		copied := authModule
+		require.NoError(t, copied.Close())
+
+		require.NoError(t, os.WriteFile(policyFile, []byte(granted), 0o600))
+		assert.Never(t, func() bool {
+			return allowed(authModule, "alice")
+		}, 300*time.Millisecond, 10*time.Millisecond)
+	})
+
+	t.Run("notifies watcher after reloads", func(t *testing.T) {
+		t.Parallel()
+
+		broadcaster := auth.NewMemoryBroadcaster()
+		var notified atomic.Int32
+		peer := broadcaster.NewWatcher()
+		require.NoError(t, peer.SetUpdateCallback(func(string) {
+			notified.Add(1)
+		}))
+		authModule, policyFile := setup(
+			t,
+			auth.WithWatcher(broadcaster.NewWatcher()),
+		)
+
+		// Own saves notify once, the file events they cause are ignored
+		require.NoError(t, authModule.CreateUserGroup("runners"))
+		time.Sleep(300 * time.Millisecond)
+		assert.Equal(t, int32(1), notified.Load())
+
+		// Rejected files do not notify
+		require.NoError(t, os.WriteFile(
+			policyFile,
+			[]byte(granted[len(baseline):]),
+			0o600,
+		))
+		time.Sleep(300 * time.Millisecond)
+		assert.Equal(t, int32(1), notified.Load())
+
+		require.NoError(t, os.WriteFile(policyFile, []byte(granted), 0o600))
+		assert.Eventually(t, func() bool {
+			return notified.Load() == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.True(t, allowed(authModule, "alice"))
+	})
+
+	t.Run("reports diff", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, policyFile := setup(t)
+		require.NoError(t, os.WriteFile(policyFile, []byte(granted), 0o600))
+
+		diff, err := authModule.ReloadPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"runners"}, diff.Added.UserGroups)
+		assert.Equal(
+			t,
+			[]auth.Membership{{Group: "runners", Member: "alice"}},
+			diff.Added.UserMemberships,
+		)
+		assert.Equal(t, []string{"runs"}, diff.Added.ResourceGroups)
+		assert.Equal(
+			t,
+			[]auth.Policy{{
+				UserGroup:     "runners",
+				ResourceGroup: "runs",
+				Permission:    "GET",
+				Effect:        auth.EffectAllow,
+			}},
+			diff.Added.Policies,
+		)
+		assert.True(t, allowed(authModule, "alice"))
+	})
+
+	t.Run("keeps stored legacy patterns", func(t *testing.T) {
+		t.Parallel()
+
+		// Patterns stored before they were validated keep loading
+		legacy := granted + "g2, /runs/*/logs, runs\n"
+		policyFile := filepath.Join(t.TempDir(), "policies.csv")
+		require.NoError(t, os.WriteFile(policyFile, []byte(legacy), 0o600))
+		authModule := auth.NewModule(
+			fileadapter.NewAdapter(policyFile),
+			auth.WithPolicyFileReload(policyFile),
+		)
+		t.Cleanup(func() { assert.NoError(t, authModule.Close()) })
+
+		require.NoError(t, os.WriteFile(
+			policyFile,
+			[]byte(legacy+"g, bob, runners\n"),
+			0o600,
+		))
+		diff, err := authModule.ReloadPolicy()
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			[]auth.Membership{{Group: "runners", Member: "bob"}},
+			diff.Added.UserMemberships,
+		)
+		assert.True(t, allowed(authModule, "bob"))
+	})
+
+	invalid := []struct {
+		name    string
+		content string
+		err     error
+	}{
+		{
+			name:    "missing admin policy",
+			content: "g, null_user, enclave_admin\n" + granted[len(baseline):],
+			err:     &auth.ConflictError{},
+		},
+		{
+			name:    "missing admin group",
+			content: "p, enclave_admin, *, *, allow\n" + granted[len(baseline):],
+			err:     &auth.ConflictError{},
+		},
+		{
+			name:    "policy for unknown group",
+			content: baseline + "p, runners, runs, GET, allow\n",
+			err:     &auth.NotFoundError{},
+		},
+		{
+			name:    "wrong number of fields",
+			content: granted + "p, runners, runs\n",
+			err:     &auth.ValidationError{},
+		},
+		{
+			name:    "unknown rule type",
+			content: granted + "g3, alice, runners\n",
+			err:     &auth.ValidationError{},
+		},
+		{
+			name:    "invalid resource pattern",
+			content: granted + "g2, /runs/*/logs, runs\n",
+			err:     &auth.ValidationError{},
+		},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			authModule, policyFile := setup(t)
+			require.NoError(t, os.WriteFile(policyFile, []byte(granted), 0o600))
+			_, err := authModule.ReloadPolicy()
+			require.NoError(t, err)
+
+			require.NoError(
+				t,
+				os.WriteFile(policyFile, []byte(tc.content), 0o600),
+			)
+			_, err = authModule.ReloadPolicy()
+			require.ErrorIs(t, err, tc.err)
+
+			// The previous state stays active
+			assert.True(t, allowed(authModule, "alice"))
+			isAdmin, err := authModule.IsAdmin(auth.UnauthenticatedUser)
+			require.NoError(t, err)
+			assert.False(t, isAdmin)
+			policies, err := authModule.ListPolicies()
+			require.NoError(t, err)
+			assert.Len(t, policies, 2)
+		})
+	}
+}
+
+func TestNestedUserGroups(t *testing.T) {
+	t.Parallel()
+
+	// alice is a runner-admin, runner-admins are runner-operators
+	setup := func(t *testing.T) auth.AuthModule {
+		t.Helper()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.Update(func(tx *auth.Tx) error {
+			return errors.Join(
+				tx.CreateUserGroup("runner-operators"),
+				tx.CreateUserGroup("runner-admins"),
+				tx.AddUserToGroup("alice", "runner-admins"),
+				tx.AddUserToGroup("bob", "runner-operators"),
+				tx.AddUserGroupToGroup("runner-admins", "runner-operators"),
+				tx.CreateResourceGroup("runs"),
+				tx.AddResourceToGroup("/runs/:id", "runs"),
+				tx.AddPolicy("runner-operators", "runs", "GET"),
+			)
+		}))
+
+		return authModule
+	}
+
+	allowed := func(module auth.AuthModule, user string) bool {
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+		ok, err := module.Check(ctx, "/runs/1", "GET")
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	t.Run("inherits permissions", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		assert.True(t, allowed(authModule, "alice"))
+		assert.True(t, allowed(authModule, "bob"))
+
+		require.NoError(t, authModule.CreateUserGroup("leads"))
+		require.NoError(t, authModule.AddUserGroupToGroup("leads", "runner-admins"))
+		require.NoError(t, authModule.AddUserToGroup("carol", "leads"))
+		assert.True(t, allowed(authModule, "carol"))
+
+		require.NoError(
+			t,
+			authModule.RemoveUserFromGroup("runner-admins", "runner-operators"),
+		)
+		assert.False(t, allowed(authModule, "alice"))
+		assert.False(t, allowed(authModule, "carol"))
+		assert.True(t, allowed(authModule, "bob"))
+	})
+
+	t.Run("lists direct and inherited memberships", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+
+		memberships, err := authModule.GetUserMemberships("alice")
+		require.NoError(t, err)
+		assert.Equal(t, auth.NestedMembership{
+			Direct:    []string{"runner-admins"},
+			Inherited: []string{"runner-operators"},
+		}, memberships)
+
+		members, err := authModule.GetUserGroupMembers("runner-operators")
+		require.NoError(t, err)
+		assert.Equal(t, auth.NestedMembership{
+			Direct:    []string{"bob", "runner-admins"},
+			Inherited: []string{"alice"},
+		}, members)
+
+		members, err = authModule.GetUserGroupMembers("runner-admins")
+		require.NoError(t, err)
+		assert.Equal(t, auth.NestedMembership{
+			Direct:    []string{"alice"},
+			Inherited: []string{},
+		}, members)
+
+		_, err = authModule.GetUserGroupMembers("missing")
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+
+		access, err := authModule.WhoCanAccess("/runs/1", "GET")
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			[]string{enclaveAdminGroup, "runner-admins", "runner-operators"},
+			access.UserGroups,
+		)
+		assert.Equal(t, []string{"alice", "bob"}, access.Users)
+	})
+
+	t.Run("rejects cycles", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		require.NoError(t, authModule.CreateUserGroup("leads"))
+		require.NoError(t, authModule.AddUserGroupToGroup("leads", "runner-admins"))
+
+		err := authModule.AddUserGroupToGroup("runner-operators", "runner-admins")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.AddUserGroupToGroup("runner-operators", "leads")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.AddUserGroupToGroup("leads", "leads")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		// Adding a group by name as a user is checked as well
+		err = authModule.AddUserToGroup("runner-operators", "leads")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+
+		err = authModule.AddUserGroupToGroup("missing", "leads")
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+
+		_, err = authModule.ApplyDocument(auth.PolicyDocument{
+			UserGroups: map[string][]string{
+				"leads": {"runner-operators"},
+			},
+		}, auth.ApplyOptions{})
+		require.ErrorIs(t, err, &auth.ConflictError{})
+
+		memberships, err := authModule.GetUserMemberships("runner-operators")
+		require.NoError(t, err)
+		assert.Empty(t, memberships.Direct)
+	})
+
+	t.Run("removing a group removes its memberships", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		require.NoError(t, authModule.RemoveUserGroup("runner-admins"))
+
+		members, err := authModule.GetUserGroupMembers("runner-operators")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob"}, members.Direct)
+		assert.Empty(t, members.Inherited)
+		assert.False(t, allowed(authModule, "alice"))
+	})
+}
+
+func TestDenyPolicies(t *testing.T) {
+	t.Parallel()
+
+	// developers may do anything on /v1/*, except using the admin endpoints
+	setup := func(t *testing.T) auth.AuthModule {
+		t.Helper()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.CreateUserGroup("developers"))
+		require.NoError(t, authModule.AddUserToGroup("alice", "developers"))
+		require.NoError(t, authModule.AddUserToGroup("root", enclaveAdminGroup))
+		require.NoError(t, authModule.AddUserToGroup("root", "developers"))
+		require.NoError(t, authModule.CreateResourceGroup("api"))
+		require.NoError(t, authModule.AddResourceToGroup("/v1/*", "api"))
+		require.NoError(t, authModule.CreateResourceGroup("admin"))
+		require.NoError(t, authModule.AddResourceToGroup("/v1/admin/*", "admin"))
+		require.NoError(t, authModule.AddPolicy("developers", "api", "*"))
+		require.NoError(t, authModule.AddDenyPolicy("developers", "admin", "*"))
+
+		return authModule
+	}
+
+	allowed := func(module auth.AuthModule, user, resource string) bool {
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+		ok, err := module.Check(ctx, resource, http.MethodGet)
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	deny := auth.Policy{
+		UserGroup:     "developers",
+		ResourceGroup: "admin",
+		Permission:    "*",
+		Effect:        auth.EffectDeny,
+	}
+
+	t.Run("deny overrides allow", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		assert.True(t, allowed(authModule, "alice", "/v1/runs"))
+		assert.False(t, allowed(authModule, "alice", "/v1/admin/users"))
+		// Deny overrides allow for admins as well
+		assert.False(t, allowed(authModule, "root", "/v1/admin/users"))
+		assert.True(t, allowed(authModule, "root", "/v1/runs"))
+
+		granted, err := authModule.BatchCheck("alice", []auth.AccessRequest{
+			{Resource: "/v1/runs", Action: http.MethodGet},
+			{Resource: "/v1/admin/users", Action: http.MethodGet},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []auth.AccessRequest{
+			{Resource: "/v1/runs", Action: http.MethodGet},
+		}, granted)
+
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.Contains(t, policies, deny)
+
+		// Removing the deny policy restores access
+		require.NoError(
+			t,
+			authModule.RemoveDenyPolicy("developers", "admin", "*"),
+		)
+		assert.True(t, allowed(authModule, "alice", "/v1/admin/users"))
+	})
+
+	t.Run("deny for enclave_admin", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		err := authModule.AddDenyPolicy(enclaveAdminGroup, "admin", "*")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+	})
+
+	t.Run("explain", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		explanation, err := authModule.Explain(
+			"alice",
+			"/v1/admin/users",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		assert.False(t, explanation.Allowed)
+		assert.Equal(t, &deny, explanation.MatchedPolicy)
+		assert.Empty(t, explanation.NearMisses)
+	})
+
+	t.Run("who can access", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		access, err := authModule.WhoCanAccess("/v1/admin/users", http.MethodGet)
+		require.NoError(t, err)
+		assert.Equal(t, []string{enclaveAdminGroup}, access.UserGroups)
+		// root is a member of developers as well
+		assert.Empty(t, access.Users)
+		assert.Equal(t, []string{"developers"}, access.DeniedUserGroups)
+		assert.Equal(t, []string{"alice", "root"}, access.DeniedUsers)
+		assert.Contains(t, access.Policies, deny)
+	})
+
+	t.Run("effective permissions", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		report, err := authModule.EffectivePermissions("alice")
+		require.NoError(t, err)
+		assert.Contains(t, report.Permissions, auth.EffectivePermission{
+			Resource: "/v1/admin/*",
+			Method:   "*",
+			Policy:   deny,
+		})
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		policyFile := filepath.Join(t.TempDir(), "policies.csv")
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o600))
+		authModule := auth.NewModule(
+			fileadapter.NewAdapter(policyFile),
+			auth.WithDefaults(config.AuthConfig{
+				UserGroups: []config.GroupConfig{
+					{Name: "developers", Members: []string{"alice"}},
+				},
+				ResourceGroups: []config.GroupConfig{
+					{Name: "admin", Members: []string{"/v1/admin/*"}},
+				},
+				Policies: []config.PolicyConfig{
+					{
+						UserGroup:     "*",
+						ResourceGroup: "*",
+						Permission:    http.MethodGet,
+					},
+					{
+						UserGroup:     "developers",
+						ResourceGroup: "admin",
+						Permission:    "*",
+						Effect:        "deny",
+					},
+				},
+			}),
+		)
+		assert.True(t, allowed(authModule, "alice", "/v1/runs"))
+		assert.False(t, allowed(authModule, "alice", "/v1/admin/users"))
+	})
+
+	t.Run("migrates legacy policies", func(t *testing.T) {
+		t.Parallel()
+
+		policyFile := filepath.Join(t.TempDir(), "policies.csv")
+		require.NoError(t, os.WriteFile(policyFile, []byte(`p, enclave_admin, *, *
+p, runners, runs, GET
+g, null_user, enclave_admin
+g, null_user, runners
+g, alice, runners
+g2, null_resource, runs
+g2, /runs/*, runs
+`), 0o600))
+
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile))
+		assert.True(t, allowed(authModule, "alice", "/runs/1"))
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []auth.Policy{
+			{
+				UserGroup:     enclaveAdminGroup,
+				ResourceGroup: "*",
+				Permission:    "*",
+				Effect:        auth.EffectAllow,
+			},
+			{
+				UserGroup:     "runners",
+				ResourceGroup: "runs",
+				Permission:    "GET",
+				Effect:        auth.EffectAllow,
+			},
+		}, policies)
+
+		content, err := os.ReadFile(policyFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "p, runners, runs, GET, allow")
+	})
+}
+
+func TestTimeBounds(t *testing.T) {
+	t.Parallel()
+
+	// runners may read runs, alice is not a runner yet
+	setup := func(t *testing.T, opts ...auth.Option) (auth.AuthModule, string) {
+		t.Helper()
+
+		policyFile := filepath.Join(t.TempDir(), "policies.csv")
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o600))
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile), opts...)
+		t.Cleanup(func() { assert.NoError(t, authModule.Close()) })
+		require.NoError(t, authModule.CreateUserGroup("runners"))
+		require.NoError(t, authModule.CreateResourceGroup("runs"))
+		require.NoError(t, authModule.AddResourceToGroup("/runs/*", "runs"))
+		require.NoError(t, authModule.AddPolicy("runners", "runs", "GET"))
+
+		return authModule, policyFile
+	}
+
+	allowed := func(module auth.AuthModule, user string) bool {
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+		ok, err := module.Check(ctx, "/runs/1", "GET")
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	t.Run("membership expires", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, policyFile := setup(t)
+		expiresAt := time.Now().Add(200 * time.Millisecond)
+		err := authModule.AddTimeBoundUserToGroup(
+			"alice",
+			auth.Validity{ExpiresAt: expiresAt},
+			"runners",
+		)
+		require.NoError(t, err)
+		assert.True(t, allowed(authModule, "alice"))
+
+		memberships, err := authModule.ListTimeBoundMemberships()
+		require.NoError(t, err)
+		require.Len(t, memberships, 1)
+		assert.Equal(
+			t,
+			auth.Membership{Group: "runners", Member: "alice"},
+			memberships[0].Membership,
+		)
+		assert.True(t, expiresAt.Equal(memberships[0].ExpiresAt))
+		assert.True(t, memberships[0].NotBefore.IsZero())
+
+		// The bounds survive a restart
+		restarted := auth.NewModule(fileadapter.NewAdapter(policyFile))
+		restartedMemberships, err := restarted.ListTimeBoundMemberships()
+		require.NoError(t, err)
+		assert.Len(t, restartedMemberships, 1)
+
+		assert.Eventually(t, func() bool {
+			return !allowed(authModule, "alice")
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// Decisions leave the expired membership stored until it is swept
+		stored, err := os.ReadFile(policyFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(stored), "g, alice, runners")
+		members, err := authModule.GetUserGroup("runners")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice"}, members)
+
+		require.NoError(t, authModule.SweepTimeBounds())
+		members, err = authModule.GetUserGroup("runners")
+		require.NoError(t, err)
+		assert.Empty(t, members)
+		memberships, err = authModule.ListTimeBoundMemberships()
+		require.NoError(t, err)
+		assert.Empty(t, memberships)
+		stored, err = os.ReadFile(policyFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored), "g, alice, runners")
+	})
+
+	t.Run("membership starts", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		err := authModule.AddTimeBoundUserToGroup("alice", auth.Validity{
+			NotBefore: time.Now().Add(200 * time.Millisecond),
+		}, "runners")
+		require.NoError(t, err)
+		assert.False(t, allowed(authModule, "alice"))
+		isAdmin, err := authModule.IsAdmin("alice")
+		require.NoError(t, err)
+		assert.False(t, isAdmin)
+
+		// The pending membership is stored, but only in effect from NotBefore
+		members, err := authModule.GetUserGroup("runners")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice"}, members)
+		assert.Eventually(t, func() bool {
+			return allowed(authModule, "alice")
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("policy expires", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		require.NoError(t, authModule.AddUserToGroup("alice", "runners"))
+		deny := auth.Policy{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    "GET",
+			Effect:        auth.EffectDeny,
+		}
+		err := authModule.AddTimeBoundPolicy(deny, auth.Validity{
+			ExpiresAt: time.Now().Add(200 * time.Millisecond),
+		})
+		require.NoError(t, err)
+		assert.False(t, allowed(authModule, "alice"))
+
+		policies, err := authModule.ListTimeBoundPolicies()
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		assert.Equal(t, deny, policies[0].Policy)
+
+		assert.Eventually(t, func() bool {
+			return allowed(authModule, "alice")
+		}, 5*time.Second, 10*time.Millisecond)
+		granted, err := authModule.BatchCheck("alice", []auth.AccessRequest{
+			{Resource: "/runs/1", Action: "GET"},
+		})
+		require.NoError(t, err)
+		assert.Len(t, granted, 1)
+
+		require.NoError(t, authModule.SweepTimeBounds())
+		listed, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.NotContains(t, listed, deny)
+	})
+
+	t.Run("invalid validity", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		now := time.Now()
+		invalid := []auth.Validity{
+			{},
+			{ExpiresAt: now.Add(-time.Minute)},
+			{NotBefore: now.Add(time.Hour), ExpiresAt: now.Add(time.Minute)},
+		}
+		for _, validity := range invalid {
+			err := authModule.AddTimeBoundUserToGroup("alice", validity, "runners")
+			require.ErrorIs(t, err, &auth.ValidationError{})
+		}
+
+		err := authModule.AddTimeBoundUserToGroup(
+			"alice",
+			auth.Validity{ExpiresAt: now.Add(time.Hour)},
+			"missing",
+		)
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+	})
+
+	t.Run("plain add makes permanent", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		err := authModule.AddTimeBoundUserToGroup(
+			"alice",
+			auth.Validity{ExpiresAt: time.Now().Add(time.Hour)},
+			"runners",
+		)
+		require.NoError(t, err)
+		require.NoError(t, authModule.AddUserToGroup("alice", "runners"))
+
+		memberships, err := authModule.ListTimeBoundMemberships()
+		require.NoError(t, err)
+		assert.Empty(t, memberships)
+		assert.True(t, allowed(authModule, "alice"))
+	})
+
+	t.Run("removals drop bounds", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		pending := auth.Validity{NotBefore: time.Now().Add(time.Hour)}
+		require.NoError(
+			t,
+			authModule.AddTimeBoundUserToGroup("alice", pending, "runners"),
+		)
+		require.NoError(t, authModule.AddTimeBoundPolicy(auth.Policy{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    "POST",
+		}, pending))
+		require.NoError(
+			t,
+			authModule.AddTimeBoundUserToGroup("bob", pending, "runners"),
+		)
+
+		require.NoError(t, authModule.RemoveUser("bob"))
+		memberships, err := authModule.ListTimeBoundMemberships()
+		require.NoError(t, err)
+		assert.Len(t, memberships, 1)
+
+		require.NoError(t, authModule.RemoveResourceGroup("runs"))
+		policies, err := authModule.ListTimeBoundPolicies()
+		require.NoError(t, err)
+		assert.Empty(t, policies)
+
+		require.NoError(t, authModule.RemoveUserGroup("runners"))
+		memberships, err = authModule.ListTimeBoundMemberships()
+		require.NoError(t, err)
+		assert.Empty(t, memberships)
+	})
+
+	t.Run("sweeper", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t, auth.WithTimeBoundSweeper(10*time.Millisecond))
+		err := authModule.AddTimeBoundUserToGroup(
+			"alice",
+			auth.Validity{ExpiresAt: time.Now().Add(100 * time.Millisecond)},
+			"runners",
+		)
+		require.NoError(t, err)
+
+		// Listing makes no decision, only the sweeper removes the membership
+		assert.Eventually(t, func() bool {
+			members, err := authModule.GetUserGroup("runners")
+			require.NoError(t, err)
+
+			return len(members) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestOwnedResources(t *testing.T) {
+	t.Parallel()
+
+	// users may read and update their own profile, but nobody else's
+	setup := func(t *testing.T) auth.AuthModule {
+		t.Helper()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.CreateUserGroup("users"))
+		require.NoError(t, authModule.AddUserToGroup("alice", "users"))
+		require.NoError(t, authModule.AddUserToGroup("bob", "users"))
+		require.NoError(t, authModule.CreateResourceGroup("own-profile"))
+		require.NoError(
+			t,
+			authModule.AddResourceToGroup("/v1/users/$user/*", "own-profile"),
+		)
+		require.NoError(t, authModule.AddPolicy("users", "own-profile", "*"))
+
+		return authModule
+	}
+
+	allowed := func(module auth.AuthModule, user, resource string) bool {
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+		ok, err := module.Check(ctx, resource, http.MethodGet)
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	t.Run("only the owner matches", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		assert.True(t, allowed(authModule, "alice", "/v1/users/alice/profile"))
+		assert.True(t, allowed(authModule, "bob", "/v1/users/bob/profile"))
+		assert.False(t, allowed(authModule, "alice", "/v1/users/bob/profile"))
+		// The placeholder is never matched literally
+		assert.False(t, allowed(authModule, "alice", "/v1/users/$user/profile"))
+		// Unauthenticated requests own nothing
+		assert.False(t, allowed(
+			authModule,
+			auth.UnauthenticatedUser,
+			"/v1/users/"+auth.UnauthenticatedUser+"/profile",
+		))
+
+		granted, err := authModule.BatchCheck("alice", []auth.AccessRequest{
+			{Resource: "/v1/users/alice/profile", Action: http.MethodGet},
+			{Resource: "/v1/users/bob/profile", Action: http.MethodGet},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []auth.AccessRequest{
+			{Resource: "/v1/users/alice/profile", Action: http.MethodGet},
+		}, granted)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		require.NoError(t, authModule.CreateResourceGroup("own-keys"))
+		require.NoError(
+			t,
+			authModule.AddResourceToGroup("/v1/users/$user/keys", "own-keys"),
+		)
+		require.NoError(t, authModule.AddDenyPolicy("*", "own-keys", "*"))
+
+		assert.True(t, allowed(authModule, "alice", "/v1/users/alice/profile"))
+		assert.False(t, allowed(authModule, "alice", "/v1/users/alice/keys"))
+	})
+
+	t.Run("explain", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		explanation, err := authModule.Explain(
+			"alice",
+			"/v1/users/alice/profile",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		assert.True(t, explanation.Allowed)
+		assert.Equal(t, []string{"own-profile"}, explanation.ResourceGroups)
+
+		explanation, err = authModule.Explain(
+			"alice",
+			"/v1/users/bob/profile",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		assert.False(t, explanation.Allowed)
+		assert.Empty(t, explanation.ResourceGroups)
+	})
+
+	t.Run("who can access", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		access, err := authModule.WhoCanAccess(
+			"/v1/users/alice/profile",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		// Only alice gets access through own-profile, not the users group
+		assert.Equal(t, []string{"alice"}, access.Users)
+		assert.Equal(t, []string{enclaveAdminGroup}, access.UserGroups)
+		assert.False(t, access.Everyone)
+
+		access, err = authModule.WhoCanAccess(
+			"/v1/users/carol/profile",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		assert.Empty(t, access.Users)
+	})
+
+	t.Run("effective permissions", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		report, err := authModule.EffectivePermissions("alice")
+		require.NoError(t, err)
+		require.Len(t, report.Permissions, 1)
+		assert.Equal(t, "/v1/users/alice/*", report.Permissions[0].Resource)
+	})
+}
+
+func TestTenants(t *testing.T) {
+	t.Parallel()
+
+	// acme and globex both have a developers group with access to /v1/runs/*
+	setup := func(t *testing.T, opts ...auth.Option) auth.AuthModule {
+		t.Helper()
+
+		policyFile := filepath.Join(t.TempDir(), "test_policy.csv")
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o644))
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile), opts...)
+		for _, tenant := range []string{"acme", "globex"} {
+			developers := auth.TenantName(tenant, "developers")
+			runs := auth.TenantName(tenant, "runs")
+			require.NoError(t, authModule.CreateTenant(tenant))
+			require.NoError(t, authModule.CreateUserGroup(developers))
+			require.NoError(t, authModule.CreateResourceGroup(runs))
+			require.NoError(t, authModule.AddResourceToGroup("/v1/runs/*", runs))
+			require.NoError(t, authModule.AddPolicy(developers, runs, "*"))
+		}
+		require.NoError(
+			t,
+			authModule.AddUserToGroup("alice", "acme::developers"),
+		)
+		require.NoError(
+			t,
+			authModule.AddUserToGroup("bob", auth.TenantAdminGroup("acme")),
+		)
+		require.NoError(t, authModule.AddUserToGroup("root", enclaveAdminGroup))
+
+		return authModule
+	}
+
+	allowed := func(module auth.AuthModule, user, tenant, resource string) bool {
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+		ctx = auth.SetTenant(ctx, tenant)
+		ok, err := module.Check(ctx, resource, http.MethodGet)
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	t.Run("policies are scoped to their tenant", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		assert.True(t, allowed(authModule, "alice", "acme", "/v1/runs/1"))
+		assert.False(t, allowed(authModule, "alice", "globex", "/v1/runs/1"))
+		assert.False(t, allowed(authModule, "alice", "", "/v1/runs/1"))
+
+		granted, err := authModule.BatchCheck("alice", []auth.AccessRequest{
+			{Resource: "/v1/runs/1", Action: http.MethodGet, Tenant: "acme"},
+			{Resource: "/v1/runs/1", Action: http.MethodGet, Tenant: "globex"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []auth.AccessRequest{
+			{Resource: "/v1/runs/1", Action: http.MethodGet, Tenant: "acme"},
+		}, granted)
+
+		explanation, err := authModule.ExplainInTenant(
+			"globex",
+			"alice",
+			"/v1/runs/1",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		assert.False(t, explanation.Allowed)
+		assert.Equal(t, "globex", explanation.Tenant)
+
+		access, err := authModule.WhoCanAccessInTenant(
+			"acme",
+			"/v1/runs/1",
+			http.MethodGet,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob", "root"}, access.Users)
+	})
+
+	t.Run("global policies apply in every tenant", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		require.NoError(t, authModule.CreateUserGroup("readers"))
+		require.NoError(t, authModule.AddUserToGroup("carol", "readers"))
+		require.NoError(t, authModule.AddPolicy("readers", "*", http.MethodGet))
+
+		assert.True(t, allowed(authModule, "carol", "acme", "/v1/runs/1"))
+		assert.True(t, allowed(authModule, "carol", "globex", "/v1/runs/1"))
+		assert.True(t, allowed(authModule, "carol", "", "/v1/runs/1"))
+	})
+
+	t.Run("admins", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		// Tenant admins have full access to the resources of their tenant only
+		assert.True(t, allowed(authModule, "bob", "acme", "/v1/runs/1"))
+		assert.False(t, allowed(authModule, "bob", "globex", "/v1/runs/1"))
+		// Resources outside the groups of the tenant are global
+		require.NoError(t, authModule.CreateResourceGroup("settings"))
+		require.NoError(
+			t,
+			authModule.AddResourceToGroup("/v1/settings", "settings"),
+		)
+		assert.False(t, allowed(authModule, "bob", "acme", "/v1/settings"))
+		assert.False(t, allowed(authModule, "bob", "acme", "/v1/anything"))
+		granted, err := authModule.BatchCheck("bob", []auth.AccessRequest{
+			{Resource: "/v1/runs/1", Action: http.MethodGet, Tenant: "acme"},
+			{Resource: "/v1/settings", Action: http.MethodGet, Tenant: "acme"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []auth.AccessRequest{
+			{Resource: "/v1/runs/1", Action: http.MethodGet, Tenant: "acme"},
+		}, granted)
+		report, err := authModule.EffectivePermissions("bob")
+		require.NoError(t, err)
+		require.Len(t, report.Permissions, 1)
+		assert.Equal(t, "/v1/runs/*", report.Permissions[0].Resource)
+		// Members of enclave_admin are super admins of all tenants
+		assert.True(t, allowed(authModule, "root", "globex", "/v1/anything"))
+
+		isAdmin, err := authModule.IsTenantAdmin("acme", "bob")
+		require.NoError(t, err)
+		assert.True(t, isAdmin)
+		isAdmin, err = authModule.IsTenantAdmin("globex", "bob")
+		require.NoError(t, err)
+		assert.False(t, isAdmin)
+		isAdmin, err = authModule.IsTenantAdmin("globex", "root")
+		require.NoError(t, err)
+		assert.True(t, isAdmin)
+
+		err = authModule.RemoveUserGroup(auth.TenantAdminGroup("acme"))
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.RemovePolicy(
+			auth.TenantAdminGroup("acme"),
+			auth.TenantName("acme", "*"),
+			"*",
+		)
+		require.ErrorIs(t, err, &auth.ConflictError{})
+	})
+
+	t.Run("migrates global tenant admin policies", func(t *testing.T) {
+		t.Parallel()
+
+		policyFile := filepath.Join(t.TempDir(), "test_policy.csv")
+		legacy := strings.Join([]string{
+			"p, enclave_admin, *, *, allow",
+			"p, acme::admin, *, *, allow",
+			"g, bob, acme::admin",
+			"g2, /v1/settings, settings",
+		}, "\n")
+		require.NoError(t, os.WriteFile(policyFile, []byte(legacy), 0o644))
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile))
+
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.Contains(t, policies, auth.Policy{
+			UserGroup:     auth.TenantAdminGroup("acme"),
+			ResourceGroup: auth.TenantName("acme", "*"),
+			Permission:    "*",
+			Effect:        auth.EffectAllow,
+		})
+		assert.False(t, allowed(authModule, "bob", "acme", "/v1/settings"))
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		err := authModule.AddPolicy("acme::developers", "globex::runs", "*")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+		err = authModule.CreateUserGroup("initech::developers")
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+		err = authModule.CreateTenant("a::b")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+		err = authModule.CreateTenant("*")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+	})
+
+	t.Run("remove tenant", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t)
+		tenants, err := authModule.ListTenants()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acme", "globex"}, tenants)
+
+		require.NoError(t, authModule.RemoveTenant("acme"))
+		assert.False(t, allowed(authModule, "alice", "acme", "/v1/runs/1"))
+		assert.False(t, allowed(authModule, "bob", "acme", "/v1/runs/1"))
+
+		tenants, err = authModule.ListTenants()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"globex"}, tenants)
+		exists, err := authModule.UserGroupExists("acme::developers")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = authModule.ResourceGroupExists("acme::runs")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		for _, policy := range policies {
+			assert.NotContains(t, policy.UserGroup, "acme::")
+		}
+
+		err = authModule.RemoveTenant("acme")
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+	})
+
+	t.Run("middleware resolves the tenant", func(t *testing.T) {
+		t.Parallel()
+
+		for _, resolved := range []struct {
+			name     string
+			resolver auth.TenantResolver
+			path     string
+			header   string
+		}{
+			{"header", auth.TenantFromHeader("X-Tenant"), "/v1/runs/1", "acme"},
+			{"path segment", auth.TenantFromPathSegment(1), "/t/acme/runs", ""},
+		} {
+			authModule := setup(t, auth.WithTenantResolver(resolved.resolver))
+			require.NoError(
+				t,
+				authModule.AddResourceToGroup("/t/acme/*", "acme::runs"),
+			)
+
+			router := gin.New()
+			router.Use(authModule.Middleware())
+			router.GET("/*path", func(c *gin.Context) {
+				assert.Equal(t, "acme", auth.GetTenant(c.Request.Context()))
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequestWithContext(
+				t.Context(),
+				http.MethodGet,
+				resolved.path,
+				http.NoBody,
+			)
+			req.Header.Set("X-Tenant", resolved.header)
+			req = req.WithContext(auth.SetAuthenticatedUser(req.Context(), "alice"))
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, http.StatusOK, res.Code, resolved.name)
+		}
+	})
+}
+
+func TestActionSets(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, opts ...auth.Option) auth.AuthModule {
+		t.Helper()
+
+		policyFile := filepath.Join(t.TempDir(), "test_policy.csv")
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o644))
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile), opts...)
+		require.NoError(t, authModule.CreateUserGroup("readers"))
+		require.NoError(t, authModule.AddUserToGroup("alice", "readers"))
+		require.NoError(t, authModule.CreateUserGroup("maintainers"))
+		require.NoError(t, authModule.AddUserToGroup("bob", "maintainers"))
+		require.NoError(t, authModule.CreateResourceGroup("runs"))
+		require.NoError(t, authModule.AddResourceToGroup("/v1/runs/*", "runs"))
+		require.NoError(
+			t,
+			authModule.AddPolicy("readers", "runs", auth.ActionRead),
+		)
+		require.NoError(
+			t,
+			authModule.AddPolicy("maintainers", "runs", auth.ActionAdmin),
+		)
+
+		return authModule
+	}
+
+	allowed := func(module auth.AuthModule, user, action string) bool {
+		ctx := auth.SetAuthenticatedUser(context.Background(), user)
+		ok, err := module.Check(ctx, "/v1/runs/1", action)
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	t.Run("no sets by default", func(t *testing.T) {
+		t.Parallel()
+
+		// Permissions named like a set only match their literal action
+		authModule := setup(t)
+		assert.False(t, allowed(authModule, "alice", http.MethodGet))
+		assert.True(t, allowed(authModule, "alice", auth.ActionRead))
+		assert.False(t, allowed(authModule, "bob", http.MethodDelete))
+		assert.False(t, allowed(authModule, "bob", auth.ActionWrite))
+		assert.True(t, allowed(authModule, "bob", auth.ActionAdmin))
+		assert.Empty(t, authModule.ActionSets())
+	})
+
+	t.Run("default sets", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t, auth.WithActionSets(auth.DefaultActionSets()))
+		assert.True(t, allowed(authModule, "alice", http.MethodGet))
+		assert.True(t, allowed(authModule, "alice", http.MethodHead))
+		assert.True(t, allowed(authModule, "alice", auth.ActionRead))
+		assert.False(t, allowed(authModule, "alice", http.MethodPost))
+		assert.False(t, allowed(authModule, "alice", auth.ActionWrite))
+		// admin includes read and write with all their methods
+		assert.True(t, allowed(authModule, "bob", http.MethodDelete))
+		assert.True(t, allowed(authModule, "bob", auth.ActionWrite))
+
+		granted, err := authModule.BatchCheck("alice", []auth.AccessRequest{
+			{Resource: "/v1/runs/1", Action: http.MethodGet},
+			{Resource: "/v1/runs/1", Action: http.MethodDelete},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []auth.AccessRequest{
+			{Resource: "/v1/runs/1", Action: http.MethodGet},
+		}, granted)
+
+		explanation, err := authModule.Explain(
+			"alice",
+			"/v1/runs/1",
+			http.MethodOptions,
+		)
+		require.NoError(t, err)
+		assert.True(t, explanation.Allowed)
+
+		access, err := authModule.WhoCanAccess("/v1/runs/1", http.MethodPatch)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"maintainers"}, slices.DeleteFunc(
+			access.UserGroups,
+			func(group string) bool { return group == enclaveAdminGroup },
+		))
+
+		assert.Equal(t, []string{
+			http.MethodDelete,
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodPatch,
+			http.MethodPost,
+			http.MethodPut,
+			auth.ActionRead,
+			auth.ActionWrite,
+		}, authModule.ActionSets()[auth.ActionAdmin])
+	})
+
+	t.Run("custom sets", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t, auth.WithActionSets(auth.ActionSets{
+			auth.ActionRead: {http.MethodGet},
+			"deploy":        {http.MethodPost, auth.ActionRead},
+		}))
+		require.NoError(t, authModule.CreateUserGroup("deployers"))
+		require.NoError(t, authModule.AddUserToGroup("carol", "deployers"))
+		require.NoError(t, authModule.AddPolicy("deployers", "runs", "deploy"))
+
+		assert.False(t, allowed(authModule, "alice", http.MethodHead))
+		assert.True(t, allowed(authModule, "carol", http.MethodPost))
+		assert.True(t, allowed(authModule, "carol", http.MethodGet))
+		assert.False(t, allowed(authModule, "carol", http.MethodDelete))
+	})
+
+	t.Run("gRPC methods", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setup(t, auth.WithGRPCActions(map[string]string{
+			"/runs.RunService/*":           auth.ActionWrite,
+			"/runs.RunService/StreamLogs":  auth.ActionRead,
+			"/runs.RunService/Internal*":   auth.ActionAdmin,
+			"/runs.RunService/InternalGet": auth.ActionRead,
+		}))
+
+		for method, action := range map[string]string{
+			"/auth.AuthAdminService/ListUserGroups":  auth.ActionRead,
+			"/auth.AuthAdminService/GetUserGroup":    auth.ActionRead,
+			"/auth.AuthAdminService/CreateUserGroup": auth.ActionWrite,
+			"/runs.RunService/GetRun":                auth.ActionWrite,
+			"/runs.RunService/StreamLogs":            auth.ActionRead,
+			"/runs.RunService/InternalReset":         auth.ActionAdmin,
+			"/runs.RunService/InternalGet":           auth.ActionRead,
+		} {
+			assert.Equal(t, action, authModule.GRPCAction(method), method)
+		}
+	})
+}
+
+func TestResourcePatterns(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+
+		for _, pattern := range []string{
+			"/",
+			"/v1/runs",
+			"/v1/runs/:id",
+			"/v1/runs/{id}",
+			"/v1/runs/{id:[0-9]+}/logs",
+			"/v1/runs/{id:[a-f]{4}}",
+			"/v1/*",
+			"/v1/run*",
+			"/v1/users/$user/*",
+			"artifact:123",
+			"*",
+		} {
+			assert.NoError(t, auth.ValidateResourcePattern(pattern), pattern)
+		}
+
+		for _, pattern := range []string{
+			"",
+			"/v1/users/:id/",
+			"/v1//runs",
+			"/v1/*/runs",
+			"/v1/ru*ns",
+			"/v1/run*/logs",
+			"/v1/runs/:",
+			"/v1/runs/:1d",
+			"/v1/runs/{id",
+			"/v1/runs/{}",
+			"/v1/runs/v{id}",
+			"/v1/runs/{id:[0-9}",
+		} {
+			err := auth.ValidateResourcePattern(pattern)
+			assert.ErrorIs(t, err, &auth.ValidationError{}, pattern)
+		}
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.CreateResourceGroup("runs"))
+		err := authModule.AddResourceToGroup("/v1/runs/:id/", "runs")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+		_, err = authModule.ApplyDocument(auth.PolicyDocument{
+			ResourceGroups: map[string][]string{"runs": {"/v1/*/logs"}},
+		}, auth.ApplyOptions{})
+		require.ErrorIs(t, err, &auth.ValidationError{})
+	})
+
+	t.Run("matching", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			pattern  string
+			resource string
+			want     bool
+		}{
+			{"/v1/runs/{id}", "/v1/runs/42", true},
+			{"/v1/runs/{id}", "/v1/runs/42/logs", false},
+			{"/v1/runs/{id:[0-9]+}/logs", "/v1/runs/42/logs", true},
+			{"/v1/runs/{id:[0-9]+}/logs", "/v1/runs/abc/logs", false},
+			{"/v1/runs/{id:[0-9]+}", "/v1/runs/42abc", false},
+			{"/v1/run*", "/v1/runs/42", true},
+			{"/v1/run*", "/v1/run", true},
+			{"/v1/run*", "/v1/jobs", false},
+			{"/v1/*", "/v1/runs/42", true},
+			{"artifact:123", "artifact:123", true},
+			{"artifact:123", "artifact:124", false},
+		}
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.CreateUserGroup("users"))
+		require.NoError(t, authModule.AddUserToGroup("alice", "users"))
+		ctx := auth.SetAuthenticatedUser(context.Background(), "alice")
+		for i, tc := range testCases {
+			group := "pattern-" + strconv.Itoa(i)
+			require.NoError(t, authModule.CreateResourceGroup(group))
+			require.NoError(t, authModule.AddResourceToGroup(tc.pattern, group))
+			require.NoError(t, authModule.AddPolicy("users", group, tc.resource))
+
+			// Each policy only grants the action named after its resource
+			allowed, err := authModule.Check(ctx, tc.resource, tc.resource)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, allowed, "%s %s", tc.pattern, tc.resource)
+		}
+	})
+}
+
+func TestPagination(t *testing.T) {
+	t.Parallel()
+
+	authModule := setupTestAuth(t)
+	for _, group := range []string{"team-c", "team-a", "ops", "team-b"} {
+		require.NoError(t, authModule.CreateUserGroup(group))
+	}
+	require.NoError(t, authModule.CreateResourceGroup("runs"))
+	require.NoError(t, authModule.AddPolicy("team-a", "runs", "GET"))
+	require.NoError(t, authModule.AddPolicy("ops", "runs", "*"))
+	notBefore := time.Now().Add(time.Hour)
+	require.NoError(t, authModule.AddTimeBoundPolicy(
+		auth.Policy{UserGroup: "team-b", ResourceGroup: "runs", Permission: "GET"},
+		auth.Validity{NotBefore: notBefore},
+	))
+
+	t.Run("filters", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := authModule.ListUserGroupsPage(
+			auth.ListOptions{Prefix: "team-"},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a", "team-b", "team-c"}, page.Items)
+		assert.Empty(t, page.NextCursor)
+
+		page, err = authModule.ListUserGroupsPage(
+			auth.ListOptions{Contains: "admin"},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{enclaveAdminGroup}, page.Items)
+	})
+
+	t.Run("cursor walks all pages", func(t *testing.T) {
+		t.Parallel()
+
+		var names []string
+		opts := auth.ListOptions{Limit: 2}
+		for {
+			page, err := authModule.ListUserGroupsPage(opts)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(page.Items), 2)
+			names = append(names, page.Items...)
+			if page.NextCursor == "" {
+				break
+			}
+			opts.Cursor = page.NextCursor
+		}
+		assert.Equal(
+			t,
+			[]string{enclaveAdminGroup, "ops", "team-a", "team-b", "team-c"},
+			names,
+		)
+	})
+
+	t.Run("pages stay stable", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+		for _, group := range []string{"a", "b", "c", "d"} {
+			require.NoError(t, authModule.CreateUserGroup(group))
+		}
+
+		page, err := authModule.ListUserGroupsPage(
+			auth.ListOptions{Limit: 2},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, page.Items)
+
+		// Removing listed entries neither skips nor repeats entries
+		require.NoError(t, authModule.RemoveUserGroup("a"))
+		require.NoError(t, authModule.RemoveUserGroup("b"))
+		page, err = authModule.ListUserGroupsPage(
+			auth.ListOptions{Limit: 2, Cursor: page.NextCursor},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c", "d"}, page.Items)
+	})
+
+	t.Run("members", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.CreateUserGroup("runners"))
+		for _, user := range []string{"carol", "alice", "bob"} {
+			require.NoError(t, authModule.AddUserToGroup(user, "runners"))
+		}
+
+		page, err := authModule.GetUserGroupPage(
+			"runners",
+			auth.ListOptions{Limit: 2},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, page.Items)
+		page, err = authModule.GetUserGroupPage(
+			"runners",
+			auth.ListOptions{Cursor: page.NextCursor},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"carol"}, page.Items)
+
+		_, err = authModule.GetUserGroupPage("missing", auth.ListOptions{})
+		assert.ErrorIs(t, err, &auth.NotFoundError{})
+	})
+
+	t.Run("policies", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := authModule.ListPoliciesPage(
+			auth.ListOptions{Prefix: "team-"},
+		)
+		require.NoError(t, err)
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, "team-a", page.Items[0].UserGroup)
+		assert.True(t, page.Items[0].Validity.NotBefore.IsZero())
+		// Time-bound policies are listed before they are in effect
+		assert.Equal(t, "team-b", page.Items[1].UserGroup)
+		assert.False(t, page.Items[1].Validity.NotBefore.IsZero())
+
+		page, err = authModule.ListPoliciesPage(auth.ListOptions{Contains: "run"})
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 3)
+
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.Equal(t, enclaveAdminGroup, policies[0].UserGroup)
+		assert.Equal(t, "ops", policies[1].UserGroup)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := authModule.ListPoliciesPage(auth.ListOptions{Limit: -1})
+		assert.ErrorIs(t, err, &auth.ValidationError{})
+		_, err = authModule.ListUserGroupsPage(
+			auth.ListOptions{Cursor: "not a cursor"},
+		)
+		assert.ErrorIs(t, err, &auth.ValidationError{})
+	})
+}
+
+func TestGroupRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("metadata", func(t *testing.T) {
+		t.Parallel()
+
+		policyFile := filepath.Join(t.TempDir(), "test_policy.csv")
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o644))
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile))
+
+		before := time.Now()
+		require.NoError(t, authModule.CreateUserGroupWithInfo(auth.GroupInfo{
+			Name:        "runners",
+			Description: "CI runners, see docs_runners",
+			Owner:       "alice",
+			CreatedBy:   "bob",
+		}))
+		require.NoError(t, authModule.CreateResourceGroup("runs"))
+
+		info, err := authModule.GetUserGroupInfo("runners")
+		require.NoError(t, err)
+		assert.Equal(t, "CI runners, see docs_runners", info.Description)
+		assert.Equal(t, "alice", info.Owner)
+		assert.Equal(t, "bob", info.CreatedBy)
+		assert.False(t, info.CreatedAt.Before(before.Truncate(time.Second)))
+
+		// Groups without members exist
+		exists, err := authModule.ResourceGroupExists("runs")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		page, err := authModule.ListResourceGroupsPage(auth.ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"runs"}, page.Items)
+
+		// Creating an existing group keeps its metadata
+		require.NoError(t, authModule.CreateUserGroup("runners"))
+		require.NoError(t, authModule.UpdateUserGroupInfo(auth.GroupInfo{
+			Name:      "runners",
+			Owner:     "carol",
+			CreatedBy: "mallory",
+		}))
+		updated, err := authModule.GetUserGroupInfo("runners")
+		require.NoError(t, err)
+		assert.Equal(t, auth.GroupInfo{
+			Name:      "runners",
+			Owner:     "carol",
+			CreatedAt: info.CreatedAt,
+			CreatedBy: "bob",
+		}, updated)
+
+		// Metadata is stored with the policies
+		_, err = authModule.ReloadPolicy()
+		require.NoError(t, err)
+		reloaded := auth.NewModule(fileadapter.NewAdapter(policyFile))
+		stored, err := reloaded.GetUserGroupInfo("runners")
+		require.NoError(t, err)
+		assert.Equal(t, updated.Owner, stored.Owner)
+		assert.True(t, updated.CreatedAt.Equal(stored.CreatedAt))
+
+		require.NoError(t, authModule.RemoveUserGroup("runners"))
+		_, err = authModule.GetUserGroupInfo("runners")
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+		err = authModule.UpdateUserGroupInfo(auth.GroupInfo{Name: "runners"})
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+	})
+
+	t.Run("migrates placeholders", func(t *testing.T) {
+		t.Parallel()
+
+		policyFile := filepath.Join(t.TempDir(), "test_policy.csv")
+		legacy := strings.Join([]string{
+			"p, enclave_admin, *, *, allow",
+			"g, null_user, enclave_admin",
+			"g, null_user, runners",
+			"g, alice, runners",
+			"g2, null_resource, runs",
+			"g2, null_resource, *",
+		}, "\n")
+		require.NoError(t, os.WriteFile(policyFile, []byte(legacy), 0o644))
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile))
+
+		page, err := authModule.ListUserGroupsPage(auth.ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{enclaveAdminGroup, "runners"}, page.Items)
+		members, err := authModule.GetUserGroup("runners")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice"}, members)
+		resources, err := authModule.GetResourceGroup("runs")
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+
+		info, err := authModule.GetUserGroupInfo("runners")
+		require.NoError(t, err)
+		assert.True(t, info.CreatedAt.IsZero())
+		assert.Empty(t, info.CreatedBy)
+
+		stored, err := os.ReadFile(policyFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored), nullUser)
+		assert.NotContains(t, string(stored), nullResource)
+	})
+}
+
+func TestRenameGroups(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour).UTC()
+	setup := func(t *testing.T) (auth.AuthModule, string) {
+		t.Helper()
+
+		policyFile := filepath.Join(t.TempDir(), "test_policy.csv")
+		require.NoError(t, os.WriteFile(policyFile, nil, 0o644))
+		authModule := auth.NewModule(fileadapter.NewAdapter(policyFile))
+
+		require.NoError(t, authModule.CreateUserGroupWithInfo(
+			auth.GroupInfo{Name: "runners", Owner: "alice"},
+		))
+		require.NoError(t, authModule.CreateUserGroup("leads"))
+		require.NoError(t, authModule.CreateUserGroup("ci"))
+		require.NoError(t, authModule.AddUserToGroup("alice", "runners"))
+		require.NoError(t, authModule.AddTimeBoundUserToGroup(
+			"bob",
+			auth.Validity{ExpiresAt: expiresAt},
+			"runners",
+		))
+		require.NoError(t, authModule.AddUserGroupToGroup("runners", "leads"))
+		require.NoError(t, authModule.AddUserToGroup("carol", "ci"))
+
+		require.NoError(t, authModule.CreateResourceGroup("runs"))
+		require.NoError(t, authModule.CreateResourceGroup("jobs"))
+		require.NoError(t, authModule.AddResourceToGroup("/runs/*", "runs"))
+		require.NoError(t, authModule.AddResourceToGroup("/jobs/*", "jobs"))
+		require.NoError(t, authModule.AddPolicy("runners", "runs", "GET"))
+		require.NoError(t, authModule.AddTimeBoundPolicy(
+			auth.Policy{
+				UserGroup:     "runners",
+				ResourceGroup: "runs",
+				Permission:    "POST",
+				Effect:        auth.EffectAllow,
+			},
+			auth.Validity{ExpiresAt: expiresAt},
+		))
+		require.NoError(t, authModule.AddPolicy("ci", "jobs", "GET"))
+		require.NoError(t, authModule.AddPolicy("ci", "runs", "GET"))
+
+		return authModule, policyFile
+	}
+
+	allowed := func(
+		t *testing.T,
+		authModule auth.AuthModule,
+		user, resource, action string,
+	) bool {
+		t.Helper()
+
+		ctx := auth.SetAuthenticatedUser(t.Context(), user)
+		ok, err := authModule.Check(ctx, resource, action)
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	t.Run("rename user group", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, policyFile := setup(t)
+		require.NoError(t, authModule.RenameUserGroup("runners", "builders"))
+
+		exists, err := authModule.UserGroupExists("runners")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		members, err := authModule.GetUserGroup("builders")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, members)
+		groups, err := authModule.GetGroupsForUser("builders")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"leads"}, groups)
+
+		info, err := authModule.GetUserGroupInfo("builders")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", info.Owner)
+
+		memberships, err := authModule.ListTimeBoundMemberships()
+		require.NoError(t, err)
+		require.Len(t, memberships, 1)
+		assert.Equal(t, "builders", memberships[0].Group)
+		assert.True(t, expiresAt.Equal(memberships[0].ExpiresAt))
+		policies, err := authModule.ListTimeBoundPolicies()
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		assert.Equal(t, "builders", policies[0].UserGroup)
+
+		assert.True(t, allowed(t, authModule, "alice", "/runs/1", "GET"))
+		assert.True(t, allowed(t, authModule, "bob", "/runs/1", "POST"))
+
+		// The rename is persisted
+		reloaded := auth.NewModule(fileadapter.NewAdapter(policyFile))
+		members, err = reloaded.GetUserGroup("builders")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, members)
+		policies, err = reloaded.ListTimeBoundPolicies()
+		require.NoError(t, err)
+		assert.Len(t, policies, 1)
+	})
+
+	t.Run("rename resource group", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		require.NoError(t, authModule.RenameResourceGroup("runs", "pipelines"))
+
+		resources, err := authModule.GetResourceGroup("pipelines")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/runs/*"}, resources)
+		policies, err := authModule.ListTimeBoundPolicies()
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		assert.Equal(t, "pipelines", policies[0].ResourceGroup)
+		assert.True(t, allowed(t, authModule, "alice", "/runs/1", "GET"))
+		assert.True(t, allowed(t, authModule, "carol", "/runs/1", "GET"))
+	})
+
+	t.Run("rename groups sharing names", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		require.NoError(t, authModule.CreateUserGroup("runs"))
+		require.NoError(t, authModule.AddUserToGroup("dave", "runs"))
+		require.NoError(t, authModule.AddPolicy("runs", "runs", "DELETE"))
+		require.NoError(t, authModule.AddResourceToGroup("jobs", "jobs"))
+
+		require.NoError(t, authModule.RenameUserGroup("runs", "operators"))
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.Contains(t, policies, auth.Policy{
+			UserGroup:     "operators",
+			ResourceGroup: "runs",
+			Permission:    "DELETE",
+			Effect:        auth.EffectAllow,
+		})
+		assert.True(t, allowed(t, authModule, "dave", "/runs/1", "DELETE"))
+
+		// The resource jobs stays a member of the renamed group jobs
+		require.NoError(t, authModule.RenameResourceGroup("jobs", "tasks"))
+		resources, err := authModule.GetResourceGroup("tasks")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/jobs/*", "jobs"}, resources)
+	})
+
+	t.Run("invalid renames", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		require.NoError(t, authModule.CreateTenant("acme"))
+
+		err := authModule.RenameUserGroup(enclaveAdminGroup, "admins")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.RenameUserGroup("runners", enclaveAdminGroup)
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.RenameUserGroup(auth.TenantAdminGroup("acme"), "admins")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.RenameUserGroup("runners", "ci")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.RenameUserGroup("missing", "builders")
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+		err = authModule.RenameUserGroup("runners", "globex::runners")
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+		err = authModule.RenameUserGroup("runners", "")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+		for _, name := range []string{"*", "run*", "/runs/:id", "{id}", "$user"} {
+			err = authModule.RenameUserGroup("runners", name)
+			require.ErrorIs(t, err, &auth.ValidationError{}, name)
+		}
+		// Wildcards cannot be created as groups either
+		err = authModule.CreateResourceGroup("*")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+		err = authModule.CreateUserGroup("acme::*")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+
+		// Nothing changed
+		members, err := authModule.GetUserGroup("runners")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, members)
+	})
+
+	t.Run("merge groups", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, _ := setup(t)
+		require.NoError(t, authModule.AddUserToGroup("alice", "ci"))
+		require.NoError(t, authModule.MergeGroups(
+			auth.UserGroupType,
+			"runners",
+			"ci",
+		))
+
+		exists, err := authModule.UserGroupExists("ci")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		members, err := authModule.GetUserGroup("runners")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob", "carol"}, members)
+		policies, err := authModule.ListPolicies()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []auth.Policy{
+			{enclaveAdminGroup, "*", "*", auth.EffectAllow},
+			{"runners", "runs", "GET", auth.EffectAllow},
+			{"runners", "runs", "POST", auth.EffectAllow},
+			{"runners", "jobs", "GET", auth.EffectAllow},
+		}, policies)
+		assert.True(t, allowed(t, authModule, "carol", "/jobs/1", "GET"))
+
+		// The metadata of the group merged into is kept
+		info, err := authModule.GetUserGroupInfo("runners")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", info.Owner)
+
+		require.NoError(t, authModule.MergeGroups(
+			auth.ResourceGroupType,
+			"runs",
+			"jobs",
+		))
+		resources, err := authModule.GetResourceGroup("runs")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/jobs/*", "/runs/*"}, resources)
+	})
+
+	t.Run("invalid merges", func(t *testing.T) {
+		t.Parallel()
+
+		authModule, policyFile := setup(t)
+
+		err := authModule.MergeGroups(
+			auth.UserGroupType,
+			"runners",
+			enclaveAdminGroup,
+		)
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.MergeGroups(auth.UserGroupType, "runners", "runners")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+		err = authModule.MergeGroups(auth.UserGroupType, "runners")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+		err = authModule.MergeGroups(auth.UserGroupType, "runners", "missing")
+		require.ErrorIs(t, err, &auth.NotFoundError{})
+		err = authModule.MergeGroups("g3", "runners", "ci")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+
+		// Groups cannot be merged into protected or admin groups
+		require.NoError(t, authModule.CreateTenant("acme"))
+		err = authModule.MergeGroups(auth.UserGroupType, enclaveAdminGroup, "ci")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		err = authModule.MergeGroups(
+			auth.UserGroupType,
+			auth.TenantAdminGroup("acme"),
+			"acme::ci",
+		)
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		members, err := authModule.GetUserGroup(enclaveAdminGroup)
+		require.NoError(t, err)
+		assert.NotContains(t, members, "carol")
+
+		// ci would become a member of itself through leads
+		require.NoError(t, authModule.AddUserGroupToGroup("leads", "ci"))
+		err = authModule.MergeGroups(auth.UserGroupType, "ci", "runners")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+
+		exists, err := authModule.UserGroupExists("runners")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		// Moved policies are validated like added ones
+		file, err := os.OpenFile(policyFile, os.O_APPEND|os.O_WRONLY, 0o644)
+		require.NoError(t, err)
+		_, err = file.WriteString("\np, ci, runs, DELETE, maybe\n")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		reloaded := auth.NewModule(fileadapter.NewAdapter(policyFile))
+		err = reloaded.MergeGroups(auth.UserGroupType, "leads", "ci")
+		require.ErrorIs(t, err, &auth.ValidationError{})
+	})
+}
+
+func TestProtect(t *testing.T) {
+	t.Parallel()
+
+	health := auth.PolicyDocument{
+		ResourceGroups: map[string][]string{"health": {"/health"}},
+		Policies: []auth.Policy{
+			{UserGroup: "*", ResourceGroup: "health", Permission: "GET"},
+		},
+	}
+	healthPolicy := auth.Policy{
+		UserGroup:     "*",
+		ResourceGroup: "health",
+		Permission:    "GET",
+		Effect:        auth.EffectAllow,
+	}
+
+	t.Run("protected entries", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.Protect("health checks", health))
+		require.NoError(t, authModule.CreateResourceGroup("status"))
+
+		ctx := auth.SetAuthenticatedUser(t.Context(), auth.UnauthenticatedUser)
+		allowed, err := authModule.Check(ctx, "/health", http.MethodGet)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		for name, err := range map[string]error{
+			"remove group": authModule.RemoveResourceGroup("health"),
+			"remove member": authModule.RemoveResourceFromGroup(
+				"/health",
+				"health",
+			),
+			"remove resource": authModule.RemoveResource("/health"),
+			"remove policy":   authModule.RemovePolicy("*", "health", "GET"),
+			"bound policy": authModule.AddTimeBoundPolicy(
+				healthPolicy,
+				auth.Validity{ExpiresAt: time.Now().Add(time.Hour)},
+			),
+			"update info": authModule.UpdateResourceGroupInfo(
+				auth.GroupInfo{Name: "health", Owner: "alice"},
+			),
+			"rename": authModule.RenameResourceGroup("health", "probes"),
+			"merge": authModule.MergeGroups(
+				auth.ResourceGroupType,
+				"status",
+				"health",
+			),
+		} {
+			require.ErrorIs(t, err, &auth.ConflictError{}, name)
+			assert.ErrorContains(t, err, "protected by health checks", name)
+		}
+
+		// Unprotected members of protected groups can change
+		require.NoError(t, authModule.AddResourceToGroup("/ready", "health"))
+		require.NoError(t, authModule.RemoveResourceFromGroup("/ready", "health"))
+
+		allowed, err = authModule.Check(ctx, "/health", http.MethodGet)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("deny policies", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.Protect("health checks", health))
+		require.NoError(t, authModule.CreateUserGroup("ops"))
+		require.NoError(t, authModule.CreateResourceGroup("probes"))
+		require.NoError(t, authModule.AddResourceToGroup("/he*", "probes"))
+		require.NoError(t, authModule.CreateResourceGroup("status"))
+		require.NoError(t, authModule.AddResourceToGroup("/status", "status"))
+
+		for name, err := range map[string]error{
+			"same policy": authModule.AddDenyPolicy("*", "health", "GET"),
+			"user group":  authModule.AddDenyPolicy("ops", "health", "GET"),
+			"resources":   authModule.AddDenyPolicy("*", "probes", "*"),
+			"time bound": authModule.AddTimeBoundPolicy(
+				auth.Policy{
+					UserGroup:     "*",
+					ResourceGroup: "health",
+					Permission:    "GET",
+					Effect:        auth.EffectDeny,
+				},
+				auth.Validity{ExpiresAt: time.Now().Add(time.Hour)},
+			),
+			"document": func() error {
+				_, err := authModule.ApplyDocument(auth.PolicyDocument{
+					ResourceGroups: map[string][]string{"all": {"/*"}},
+					Policies: []auth.Policy{{
+						UserGroup:     "ops",
+						ResourceGroup: "all",
+						Permission:    "*",
+						Effect:        auth.EffectDeny,
+					}},
+				}, auth.ApplyOptions{})
+
+				return err
+			}(),
+		} {
+			require.ErrorIs(t, err, &auth.ConflictError{}, name)
+			assert.ErrorContains(t, err, "protected by health checks", name)
+		}
+
+		exists, err := authModule.ResourceGroupExists("all")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		// Deny policies not overlapping protected policies can be added
+		require.NoError(t, authModule.AddDenyPolicy("*", "health", "POST"))
+		require.NoError(t, authModule.AddDenyPolicy("*", "status", "GET"))
+
+		ctx := auth.SetAuthenticatedUser(t.Context(), auth.UnauthenticatedUser)
+		allowed, err := authModule.Check(ctx, "/health", http.MethodGet)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		// Nor can documents be protected if deny policies override them
+		authModule = setupTestAuth(t)
+		require.NoError(t, authModule.AddDenyPolicy("*", "*", "GET"))
+		err = authModule.Protect("health checks", health)
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		assert.ErrorContains(t, err, "protected by health checks")
+		exists, err = authModule.ResourceGroupExists("health")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("enclave_admin", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+
+		err := authModule.RemoveUserGroup(enclaveAdminGroup)
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		assert.ErrorContains(t, err, "protected by enclave_admin")
+		err = authModule.RemovePolicy(enclaveAdminGroup, "*", "*")
+		require.ErrorIs(t, err, &auth.ConflictError{})
+		require.NoError(t, authModule.CreateUserGroup("admins"))
+		err = authModule.MergeGroups(
+			auth.UserGroupType,
+			"admins",
+			enclaveAdminGroup,
+		)
+		require.ErrorIs(t, err, &auth.ConflictError{})
+
+		// Members of enclave_admin are not protected
+		require.NoError(t, authModule.AddUserToGroup("alice", enclaveAdminGroup))
+		require.NoError(
+			t,
+			authModule.RemoveUserFromGroup("alice", enclaveAdminGroup),
+		)
+	})
+
+	t.Run("pruning keeps protected entries", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.Protect("health checks", health))
+		require.NoError(t, authModule.AddResourceToGroup("/ready", "health"))
+
+		diff, err := authModule.ApplyDocument(
+			auth.PolicyDocument{},
+			auth.ApplyOptions{Prune: true},
+		)
+		require.NoError(t, err)
+		assert.Empty(t, diff.Removed.ResourceGroups)
+		assert.Empty(t, diff.Removed.Policies)
+
+		resources, err := authModule.GetResourceGroup("health")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/health", "/ready"}, resources)
+	})
+
+	t.Run("protected rules are permanent", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+		require.NoError(t, authModule.CreateResourceGroup("health"))
+		require.NoError(t, authModule.AddTimeBoundPolicy(
+			healthPolicy,
+			auth.Validity{ExpiresAt: time.Now().Add(time.Hour)},
+		))
+
+		require.NoError(t, authModule.Protect("health checks", health))
+		policies, err := authModule.ListTimeBoundPolicies()
+		require.NoError(t, err)
+		assert.Empty(t, policies)
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		t.Parallel()
+
+		authModule := setupTestAuth(t)
+
+		err := authModule.Update(func(tx *auth.Tx) error {
+			require.NoError(t, tx.Protect("health checks", health))
+
+			return errors.New("abort")
+		})
+		require.EqualError(t, err, "abort")
+		require.NoError(t, authModule.CreateResourceGroup("health"))
+		require.NoError(t, authModule.RemoveResourceGroup("health"))
+
+		err = authModule.Protect("", health)
+		require.ErrorIs(t, err, &auth.ValidationError{})
+	})
+}
+
+// Benchmark tests
+func BenchmarkInitAuth(b *testing.B) {
+	tempDir := b.TempDir()
+	tempFile := filepath.Join(tempDir, "bench_policy.csv")
+
+	for range b.N {
+		adapter := fileadapter.NewAdapter(tempFile)
+		auth.NewModule(adapter)
+	}
+}
+
+func BenchmarkAddUserToGroup(b *testing.B) {
+	tempDir := b.TempDir()
+	tempFile := filepath.Join(tempDir, "bench_policy.csv")
+
+	// Create the file
+	file, err := os.Create(tempFile)
+	require.NoError(b, err)
+	err = file.Close()
+	require.NoError(b, err)
+
+	adapter := fileadapter.NewAdapter(tempFile)
+	authModule := auth.NewModule(adapter)
+	err = authModule.CreateUserGroup("benchGroup")
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for range b.N {
+		err = authModule.AddUserToGroup("benchUser", "benchGroup")
+		require.NoError(b, err)
+		err = authModule.RemoveUser("benchUser")
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkAddPolicy(b *testing.B) {
+	tempDir := b.TempDir()
+	tempFile := filepath.Join(tempDir, "bench_policy.csv")
+
+	// Create the file
+	file, err := os.Create(tempFile)
+	require.NoError(b, err)
+	err = file.Close()
+	require.NoError(b, err)
+
+	adapter := fileadapter.NewAdapter(tempFile)
+	authModule := auth.NewModule(adapter)
+	err = authModule.CreateUserGroup("benchUserGroup")
+	require.NoError(b, err)
+	err = authModule.CreateResourceGroup("benchResourceGroup")
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for range b.N {
+		err = authModule.AddPolicy("benchUserGroup", "benchResourceGroup", "GET")
+		require.NoError(b, err)
+		err = authModule.RemovePolicy("benchUserGroup", "benchResourceGroup", "GET")
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkBatchCheck(b *testing.B) {
+	tempDir := b.TempDir()
+	tempFile := filepath.Join(tempDir, "bench_policy.csv")
+
+	// Create the file
+	file, err := os.Create(tempFile)
+	require.NoError(b, err)
+	err = file.Close()
+	require.NoError(b, err)
+
+	adapter := fileadapter.NewAdapter(tempFile)
+	authModule := auth.NewModule(adapter)
+	err = authModule.CreateUserGroup("benchUserGroup")
+	require.NoError(b, err)
+	err = authModule.CreateResourceGroup("benchResourceGroup")
+	require.NoError(b, err)
+	err = authModule.AddUserToGroup("benchUser", "benchUserGroup")
+	require.NoError(b, err)
+	err = authModule.AddResourceToGroup("/v1/runs/:id", "benchResourceGroup")
+	require.NoError(b, err)
+	err = authModule.AddPolicy("benchUserGroup", "benchResourceGroup", "GET")
+	require.NoError(b, err)
+
+	requests := make([]auth.AccessRequest, 500)
+	for i := range requests {
+		requests[i] = auth.AccessRequest{
+			Resource: "/v1/runs/" + strconv.Itoa(i),
+			Action:   "GET",
+		}
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		_, err = authModule.BatchCheck("benchUser", requests)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkCheckAtScale measures Check of a user with a time-bound membership
+// against growing numbers of policies of other groups.
+func BenchmarkCheckAtScale(b *testing.B) {
+	for _, size := range []int{100, 1000} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			lines := [][]string{}
+			for i := range size {
+				group := "group" + strconv.Itoa(i)
+				lines = append(
+					lines,
+					[]string{"g", "user" + strconv.Itoa(i), group},
+					[]string{"g2", "/v1/runs/" + strconv.Itoa(i), group},
+					[]string{"p", group, group, "GET", "allow"},
+				)
+			}
+			authModule := auth.NewModule(newMemoryAdapter(lines...))
+			err := authModule.AddTimeBoundUserToGroup(
+				"benchUser",
+				auth.Validity{ExpiresAt: time.Now().Add(time.Hour)},
+				"group0",
+			)
+			require.NoError(b, err)
+			ctx := auth.SetAuthenticatedUser(context.Background(), "benchUser")
+
+			b.ResetTimer()
+			for range b.N {
+				allowed, err := authModule.Check(ctx, "/v1/runs/0", "GET")
+				require.NoError(b, err)
+				require.True(b, allowed)
+			}
+		})
+	}
+}
+
+// BenchmarkMutationAtScale measures AddPolicy and RemovePolicy with large
+// policy sets, persisted by full saves to a file and by auto save.
+func BenchmarkMutationAtScale(b *testing.B) {
+	for _, size := range []int{10_000, 100_000} {
+		lines := [][]string{
+			{"g", nullUser, "benchUserGroup"},
+			{"g2", nullResource, "benchResourceGroup"},
+		}
+		for i := range size {
+			lines = append(lines, []string{
+				"p", "benchUserGroup", "benchResourceGroup", "M" + strconv.Itoa(i),
+			})
+		}
+
+		b.Run("file/"+strconv.Itoa(size), func(b *testing.B) {
+			var content strings.Builder
+			for _, line := range lines {
+				content.WriteString(strings.Join(line, ", ") + "\n")
+			}
+			tempFile := filepath.Join(b.TempDir(), "bench_policy.csv")
+			err := os.WriteFile(tempFile, []byte(content.String()), 0o644)
+			require.NoError(b, err)
+
+			benchmarkMutation(b, auth.NewModule(fileadapter.NewAdapter(tempFile)))
+		})
+
+		b.Run("autosave/"+strconv.Itoa(size), func(b *testing.B) {
+			benchmarkMutation(b, auth.NewModule(newMemoryAdapter(lines...)))
+		})
+	}
+}
+
+func benchmarkMutation(b *testing.B, authModule auth.AuthModule) {
+	b.Helper()
+
+	b.ResetTimer()
+	for range b.N {
+		err := authModule.AddPolicy("benchUserGroup", "benchResourceGroup", "GET")
 		require.NoError(b, err)
 		err = authModule.RemovePolicy("benchUserGroup", "benchResourceGroup", "GET")
 		require.NoError(b, err)
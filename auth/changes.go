@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"github.com/rs/zerolog/log"
+)
+
+// ruleChange adds a rule to or removes it from one of the policy types p, g
+// or g2, or the stored time bounds.
+type ruleChange struct {
+	ptype  string
+	rule   []string
+	remove bool
+}
+
+func (c ruleChange) inverse() ruleChange {
+	return ruleChange{ptype: c.ptype, rule: c.rule, remove: !c.remove}
+}
+
+// mutation is a validated set of rule changes made by a single operation.
+type mutation struct {
+	action  string
+	changes []ruleChange
+	// keepBounds leaves the time bounds of the changed rules untouched, see
+	// withTimeBounds.
+	keepBounds bool
+}
+
+func (m *mutation) add(ptype string, rule ...string) {
+	m.changes = append(m.changes, ruleChange{ptype: ptype, rule: rule})
+}
+
+func (m *mutation) remove(ptype string, rule ...string) {
+	m.changes = append(
+		m.changes,
+		ruleChange{ptype: ptype, rule: rule, remove: true},
+	)
+}
+
+// Tx stages mutations of an AuthModule, see Update. Reads through the embedded
+// AuthModule already see the staged mutations.
+type Tx struct {
+	AuthModule
+
+	applied []ruleChange
+	// protected are added to the protections of the module on commit
+	protected protections
+}
+
+// Update runs fn in a transaction. Mutations made through tx are applied in
+// memory right away, so later ones are validated against them, and persisted
+// together once fn returns: only the changed rules if the adapter supports
+// auto save, with a single full save otherwise. If fn or the save fails, all
+// mutations are rolled back and the error is returned. Calling Update on a Tx
+// joins the running transaction. Once committed, the watcher set with
+// WithWatcher is notified.
+//
+// The module is locked for the whole transaction, so fn must only use tx and
+// not the module Update was called on.
+func (auth *AuthModule) Update(fn func(tx *Tx) error) error {
+	if auth.tx != nil {
+		return fn(auth.tx)
+	}
+
+	changed, err := auth.commit(fn)
+	if err != nil {
+		return err
+	}
+
+	// Notify without holding the lock, peers may call back synchronously
+	if changed {
+		auth.notifyWatcher()
+	}
+
+	return nil
+}
+
+// commit runs and persists the transaction of Update and reports whether any
+// rules changed.
+func (auth *AuthModule) commit(fn func(tx *Tx) error) (bool, error) {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	tx := &Tx{AuthModule: *auth}
+	tx.tx = tx
+
+	err := fn(tx)
+	if err == nil && len(tx.applied) > 0 {
+		err = auth.persister.save(auth.enforcer, tx.applied)
+	}
+	if err != nil {
+		auth.revertChanges(tx.applied)
+		log.Debug().
+			Err(err).
+			Int("changes", len(tx.applied)).
+			Msg("Rolled back auth transaction")
+
+		return false, err
+	}
+	*auth.protections = append(*auth.protections, tx.protected...)
+	if auth.lifecycle.reloader != nil && len(tx.applied) > 0 {
+		auth.lifecycle.reloader.recordSave()
+	}
+
+	return len(tx.applied) > 0, nil
+}
+
+// mutate plans a mutation and applies it, directly persisted or staged in the
+// running transaction. Planning and applying happen under the write lock, so
+// the checks done while planning still hold when the mutation is applied. plan
+// must not call locking methods of the module.
+func (auth *AuthModule) mutate(plan func() (mutation, error)) error {
+	return auth.Update(func(tx *Tx) error {
+		m, err := plan()
+		if err != nil {
+			return err
+		}
+		if !m.keepBounds {
+			m, err = auth.withTimeBounds(m)
+			if err != nil {
+				return err
+			}
+		}
+		err = auth.protections.check(m.changes)
+		if err != nil {
+			return err
+		}
+
+		start := len(tx.applied)
+		for _, change := range m.changes {
+			ok, err := auth.applyChange(change)
+			if err != nil {
+				return &CasbinError{m.action, err}
+			}
+			if ok {
+				tx.applied = append(tx.applied, change)
+			}
+		}
+
+		// Checked once applied, deny policies may name groups and resources
+		// added by the same mutation
+		err = auth.checkOverrides(*auth.protections, m.changes)
+		if err != nil {
+			auth.revertChanges(tx.applied[start:])
+			tx.applied = tx.applied[:start]
+
+			return err
+		}
+
+		return nil
+	})
+}
+
+// rlock locks the module for reading and returns the matching unlock. Within a
+// transaction the write lock is already held and nothing is locked.
+func (auth *AuthModule) rlock() func() {
+	if auth.tx != nil {
+		return func() {}
+	}
+
+	auth.mu.RLock()
+
+	return auth.mu.RUnlock
+}
+
+// applyChange applies a single change in memory and reports whether the rules
+// changed.
+func (auth *AuthModule) applyChange(change ruleChange) (bool, error) {
+	var (
+		ok  bool
+		err error
+	)
+	isPolicy := change.ptype[:1] == "p"
+	switch {
+	case isPolicy && change.remove:
+		ok, err = auth.enforcer.RemoveNamedPolicy(change.ptype, change.rule)
+	case isPolicy:
+		ok, err = auth.enforcer.AddNamedPolicy(change.ptype, change.rule)
+	case change.remove:
+		ok, err = auth.enforcer.RemoveNamedGroupingPolicy(
+			change.ptype,
+			change.rule,
+		)
+	default:
+		ok, err = auth.enforcer.AddNamedGroupingPolicy(change.ptype, change.rule)
+	}
+	if ok {
+		auth.decisions.invalidate()
+	}
+
+	return ok, err //nolint:wrapcheck // Wrapped by mutate
+}
+
+// revertChanges undoes the applied changes in reverse order.
+func (auth *AuthModule) revertChanges(applied []ruleChange) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		_, _ = auth.applyChange(applied[i].inverse())
+	}
+}
@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// AccessRequest describes an action on a resource that is checked by
+// BatchCheck.
+type AccessRequest struct {
+	Resource string
+	Action   string
+	// Tenant is the tenant the request is made in, "" for none.
+	Tenant string
+}
+
+// Check reports whether the authenticated user stored in ctx may perform
+// action on resource in the tenant stored in ctx. It uses the same enforcer as
+// Middleware, so resource can be a request path or an arbitrary object name
+// like "artifact:123".
+func (auth *AuthModule) Check(
+	ctx context.Context,
+	resource, action string,
+) (bool, error) {
+	return auth.enforce(
+		GetAuthenticatedUser(ctx),
+		GetTenant(ctx),
+		resource,
+		action,
+	)
+}
+
+// Require is like Check but returns a ForbiddenError if the authenticated user
+// is not allowed to perform action on resource.
+func (auth *AuthModule) Require(
+	ctx context.Context,
+	resource, action string,
+) error {
+	user := GetAuthenticatedUser(ctx)
+
+	allowed, err := auth.enforce(user, GetTenant(ctx), resource, action)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &ForbiddenError{user, resource, action}
+	}
+
+	return nil
+}
+
+// IsAdmin reports whether user is a member of the enclave_admin group.
+func (auth *AuthModule) IsAdmin(user string) (bool, error) {
+	defer auth.rlock()()
+
+	groups, err := auth.groupsInEffect(user, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(groups, enclaveAdminGroup), nil
+}
+
+// BatchCheck evaluates all requests for user and returns the allowed subset
+// in the original order. The user's groups are resolved once for the whole
+// batch instead of once per request.
+func (auth *AuthModule) BatchCheck(
+	user string,
+	requests []AccessRequest,
+) ([]AccessRequest, error) {
+	defer auth.rlock()()
+
+	index, err := auth.decisions.load()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	allowed := make([]AccessRequest, 0, len(requests))
+	for _, request := range requests {
+		ok, err := auth.decide(
+			index,
+			now,
+			user,
+			request.Tenant,
+			request.Resource,
+			request.Action,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			allowed = append(allowed, request)
+		}
+	}
+
+	return allowed, nil
+}
+
+// enforce decides a single request, see decide.
+func (auth *AuthModule) enforce(
+	user, tenant, resource, action string,
+) (bool, error) {
+	defer auth.rlock()()
+
+	index, err := auth.decisions.load()
+	if err != nil {
+		return false, err
+	}
+
+	return auth.decide(index, time.Now(), user, tenant, resource, action)
+}
+
+// decide reports whether user may perform action on resource in tenant at
+// now: an allow policy has to cover the request and no deny policy may. Like
+// the casbin matcher Explain runs, but only the policies of the user, the
+// user's groups and "*" are looked at, and deny policies only once an allow
+// policy covers the request.
+func (auth *AuthModule) decide(
+	index *decisionIndex,
+	now time.Time,
+	user, tenant, resource, action string,
+) (bool, error) {
+	subjects, err := index.subjects(user, now)
+	if err != nil {
+		return false, err
+	}
+
+	for _, effect := range []Effect{EffectAllow, EffectDeny} {
+		covered := false
+		for _, subject := range subjects {
+			for _, policy := range index.policies[subject] {
+				if policy.Effect != effect ||
+					!index.policyInEffect(policy, now) ||
+					!policy.appliesIn(tenant) {
+					continue
+				}
+
+				covered, err = auth.policyCovers(policy, user, resource, action)
+				if err != nil {
+					return false, err
+				}
+				if covered {
+					break
+				}
+			}
+			if covered {
+				break
+			}
+		}
+		if covered != (effect == EffectAllow) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// subjectPolicies returns all policies in effect whose subject is the user, one
+// of the user's groups or the "*" wildcard.
+func (auth *AuthModule) subjectPolicies(user string) ([]Policy, error) {
+	index, err := auth.decisions.load()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	subjects, err := index.subjects(user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := []Policy{}
+	for _, subject := range subjects {
+		for _, policy := range index.policies[subject] {
+			if index.policyInEffect(policy, now) {
+				policies = append(policies, policy)
+			}
+		}
+	}
+
+	return policies, nil
+}
+
+// policyCovers reports whether policy grants user action on resource. It
+// mirrors the object and action part of the casbin matcher.
+func (auth *AuthModule) policyCovers(
+	policy Policy,
+	user, resource, action string,
+) (bool, error) {
+	if !auth.actionCovers(policy.Permission, action) {
+		return false, nil
+	}
+	if policy.ResourceGroup == "*" {
+		return true, nil
+	}
+
+	return auth.inResourceGroup(user, resource, policy.ResourceGroup)
+}
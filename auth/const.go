@@ -58,3 +58,38 @@ func (e *ConflictError) Is(target error) bool {
 
 	return ok
 }
+
+type ForbiddenError struct {
+	User     string
+	Resource string
+	Action   string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf(
+		"forbidden: %s is not allowed to %s %s",
+		e.User,
+		e.Action,
+		e.Resource,
+	)
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	_, ok := target.(*ForbiddenError)
+
+	return ok
+}
+
+type ValidationError struct {
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid: " + e.Reason
+}
+
+func (e *ValidationError) Is(target error) bool {
+	_, ok := target.(*ValidationError)
+
+	return ok
+}
@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"slices"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/casbin/casbin/v3"
+)
+
+// groupCacheSize is the number of users whose groups a decisionIndex holds.
+const groupCacheSize = 4096
+
+// decisions keeps what access decisions need resolved until the rules change:
+// the policies by subject, the time bounds of all rules and the groups of
+// recently requesting users. Decisions look them up instead of resolving them
+// for every policy. It is shared by all copies of a module.
+type decisions struct {
+	enforcer *casbin.Enforcer
+	current  atomic.Pointer[decisionIndex]
+}
+
+// decisionIndex is what decisions resolved from the rules at one point.
+type decisionIndex struct {
+	enforcer *casbin.Enforcer
+	// policies are all policies keyed by their subject, in stored order.
+	policies         map[string][]Policy
+	policyBounds     map[string]Validity
+	membershipBounds map[string]Validity
+	// transitions are the sorted times membership bounds start or end at. The
+	// groups of a user only change at them.
+	transitions []time.Time
+	groups      *lruCache[resolvedGroups]
+}
+
+// resolvedGroups are the groups of a user from since until the next
+// transition at until, zero if there is none.
+type resolvedGroups struct {
+	names        []string
+	set          map[string]bool
+	since, until time.Time
+}
+
+func newDecisions(enforcer *casbin.Enforcer) *decisions {
+	return &decisions{enforcer: enforcer}
+}
+
+// load returns the index of the current rules and builds it if the rules
+// changed since. Callers hold the lock of the module, so the rules cannot
+// change while it is built.
+func (d *decisions) load() (*decisionIndex, error) {
+	index := d.current.Load()
+	if index != nil {
+		return index, nil
+	}
+
+	index, err := newDecisionIndex(d.enforcer)
+	if err != nil {
+		return nil, err
+	}
+	d.current.Store(index)
+
+	return index, nil
+}
+
+// invalidate drops the index after the rules changed.
+func (d *decisions) invalidate() {
+	d.current.Store(nil)
+}
+
+func newDecisionIndex(enforcer *casbin.Enforcer) (*decisionIndex, error) {
+	rules, err := enforcer.GetPolicy()
+	if err != nil {
+		return nil, &CasbinError{"GetPolicy", err}
+	}
+	policies := map[string][]Policy{}
+	for _, rule := range rules {
+		policy := policyFromRule(rule)
+		policies[policy.UserGroup] = append(policies[policy.UserGroup], policy)
+	}
+
+	policyBounds, err := validities(enforcer, "p")
+	if err != nil {
+		return nil, err
+	}
+	membershipBounds, err := validities(enforcer, string(UserGroupType))
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []time.Time
+	for _, validity := range membershipBounds {
+		for _, t := range []time.Time{validity.NotBefore, validity.ExpiresAt} {
+			if !t.IsZero() {
+				transitions = append(transitions, t)
+			}
+		}
+	}
+	slices.SortFunc(transitions, time.Time.Compare)
+
+	return &decisionIndex{
+		enforcer:         enforcer,
+		policies:         policies,
+		policyBounds:     policyBounds,
+		membershipBounds: membershipBounds,
+		transitions:      slices.CompactFunc(transitions, time.Time.Equal),
+		groups:           newLRUCache[resolvedGroups](groupCacheSize),
+	}, nil
+}
+
+// groupsOf returns the user groups user belongs to at now, see
+// groupsInEffect. The result must not be modified.
+func (index *decisionIndex) groupsOf(
+	user string,
+	now time.Time,
+) (resolvedGroups, error) {
+	cached, ok := index.groups.load(user)
+	if ok && !now.Before(cached.since) &&
+		(cached.until.IsZero() || now.Before(cached.until)) {
+		return cached, nil
+	}
+
+	names, err := linksInEffect(
+		index.enforcer,
+		index.membershipBounds,
+		user,
+		now,
+		false,
+	)
+	if err != nil {
+		return resolvedGroups{}, err
+	}
+
+	resolved := resolvedGroups{
+		names: names,
+		set:   make(map[string]bool, len(names)),
+	}
+	for _, name := range names {
+		resolved.set[name] = true
+	}
+	next := sort.Search(len(index.transitions), func(i int) bool {
+		return index.transitions[i].After(now)
+	})
+	if next > 0 {
+		resolved.since = index.transitions[next-1]
+	}
+	if next < len(index.transitions) {
+		resolved.until = index.transitions[next]
+	}
+	index.groups.store(user, resolved)
+
+	return resolved, nil
+}
+
+// subjects returns the policy subjects that stand for user at now: the user,
+// the "*" wildcard and the user's groups.
+func (index *decisionIndex) subjects(
+	user string,
+	now time.Time,
+) ([]string, error) {
+	groups, err := index.groupsOf(user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{user, "*"}, groups.names...), nil
+}
+
+// policyInEffect reports whether policy is within its time bounds at now.
+// Policies without bounds are always in effect.
+func (index *decisionIndex) policyInEffect(policy Policy, now time.Time) bool {
+	validity, bounded := index.policyBounds[ruleKey("p", policy.rule())]
+
+	return !bounded || validity.inEffect(now)
+}
+
+// groupsInEffect returns the user groups user belongs to at now, directly or
+// through nested groups. Memberships outside their time bounds are left out,
+// so decisions never depend on when expired ones are swept.
+func (auth *AuthModule) groupsInEffect(
+	user string,
+	now time.Time,
+) ([]string, error) {
+	index, err := auth.decisions.load()
+	if err != nil {
+		return nil, err
+	}
+	groups, err := index.groupsOf(user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(groups.names), nil
+}
+
+// membersInEffect returns the users and user groups that belong to group at
+// now, directly or through nested groups, like groupsInEffect.
+func (auth *AuthModule) membersInEffect(
+	group string,
+	now time.Time,
+) ([]string, error) {
+	index, err := auth.decisions.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return linksInEffect(
+		auth.enforcer,
+		index.membershipBounds,
+		group,
+		now,
+		true,
+	)
+}
+
+// policiesInEffect returns the policies that are within their time bounds at
+// now.
+func (auth *AuthModule) policiesInEffect(
+	policies []Policy,
+	now time.Time,
+) ([]Policy, error) {
+	index, err := auth.decisions.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(policies, func(policy Policy) bool {
+		return !index.policyInEffect(policy, now)
+	}), nil
+}
@@ -0,0 +1,571 @@
+package auth
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.yaml.in/yaml/v3"
+)
+
+// PolicyDocument declaratively describes user groups, resource groups, their
+// members and policies. Groups map to their members.
+type PolicyDocument struct {
+	UserGroups     map[string][]string `json:"userGroups,omitempty"     yaml:"userGroups,omitempty"`
+	ResourceGroups map[string][]string `json:"resourceGroups,omitempty" yaml:"resourceGroups,omitempty"`
+	Policies       []Policy            `json:"policies,omitempty"       yaml:"policies,omitempty"`
+
+	// registry holds the stored registry rules of the groups by group type if
+	// the document was read from rules, see documentFromModel. It keeps their
+	// GroupInfo, which documents do not describe otherwise.
+	registry map[GroupType]map[string][]string
+}
+
+// Membership is a user or resource in a group.
+type Membership struct {
+	Group  string `json:"group"`
+	Member string `json:"member"`
+}
+
+// PolicyChanges lists groups, memberships and policies added or removed by a
+// PolicyDiff. Memberships of removed groups are not listed separately.
+type PolicyChanges struct {
+	UserGroups          []string     `json:"userGroups"`
+	UserMemberships     []Membership `json:"userMemberships"`
+	ResourceGroups      []string     `json:"resourceGroups"`
+	ResourceMemberships []Membership `json:"resourceMemberships"`
+	Policies            []Policy     `json:"policies"`
+}
+
+// PolicyDiff is the difference between a PolicyDocument and the live state.
+type PolicyDiff struct {
+	Added   PolicyChanges `json:"added"`
+	Removed PolicyChanges `json:"removed"`
+
+	changes []ruleChange
+}
+
+// ApplyOptions control how ApplyDocument treats the live state.
+type ApplyOptions struct {
+	// DryRun only computes the diff without changing anything.
+	DryRun bool
+	// Prune removes groups, memberships of groups in the document and policies
+	// that are not part of the document. Protected entries, like the
+	// enclave_admin group and policy, are never removed, see Protect. Other
+	// members of protected groups only if the document lists the group.
+	Prune bool
+}
+
+// ParsePolicyDocument reads a PolicyDocument in YAML or JSON format. Unknown
+// fields are rejected.
+func ParsePolicyDocument(r io.Reader) (PolicyDocument, error) {
+	var document PolicyDocument
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	err := decoder.Decode(&document)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return PolicyDocument{}, fmt.Errorf("parse policy document: %w", err)
+	}
+
+	return document, nil
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d PolicyDiff) IsEmpty() bool {
+	return len(d.changes) == 0
+}
+
+// DiffDocument computes the changes ApplyDocument would make.
+func (auth *AuthModule) DiffDocument(
+	document PolicyDocument,
+	prune bool,
+) (PolicyDiff, error) {
+	return auth.ApplyDocument(document, ApplyOptions{DryRun: true, Prune: prune})
+}
+
+// ApplyDocument changes the live state to match document and returns the
+// applied diff. Without pruning, entries missing from the document are kept.
+// All changes are applied and persisted at once, or staged when called on a Tx;
+// if the document is invalid or persisting fails, nothing is changed.
+func (auth *AuthModule) ApplyDocument(
+	document PolicyDocument,
+	opts ApplyOptions,
+) (PolicyDiff, error) {
+	var diff PolicyDiff
+	err := auth.mutate(func() (mutation, error) {
+		var err error
+		diff, err = auth.diffDocument(document, opts.Prune)
+		if err != nil || opts.DryRun {
+			return mutation{}, err
+		}
+
+		return mutation{action: "ApplyDocument", changes: diff.changes}, nil
+	})
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+	if opts.DryRun {
+		return diff, nil
+	}
+
+	log.Info().
+		Int("changes", len(diff.changes)).
+		Bool("prune", opts.Prune).
+		Msg("Applied policy document")
+
+	return diff, nil
+}
+
+func (auth *AuthModule) diffDocument(
+	document PolicyDocument,
+	prune bool,
+) (PolicyDiff, error) {
+	userRegistry, err := auth.userGroupManager.registrations()
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+	resourceRegistry, err := auth.resourceGroupManager.registrations()
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+	liveUserGroups, err := auth.groupMembers(
+		string(UserGroupType),
+		userRegistry,
+	)
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+	liveResourceGroups, err := auth.groupMembers(
+		string(ResourceGroupType),
+		resourceRegistry,
+	)
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+
+	protected := *auth.protections
+	err = validateDocument(
+		document,
+		liveUserGroups,
+		liveResourceGroups,
+		prune,
+		protected,
+	)
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+
+	diff := PolicyDiff{}
+	diff.diffGroups(
+		UserGroupType,
+		document.UserGroups,
+		liveUserGroups,
+		groupRegistries{document.registry[UserGroupType], userRegistry},
+		prune,
+		protected,
+	)
+	diff.diffGroups(
+		ResourceGroupType,
+		document.ResourceGroups,
+		liveResourceGroups,
+		groupRegistries{document.registry[ResourceGroupType], resourceRegistry},
+		prune,
+		protected,
+	)
+
+	livePolicies, err := auth.listPolicies()
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+	diff.diffPolicies(document.Policies, livePolicies, prune, protected)
+
+	return diff, nil
+}
+
+// groupMembers returns the members of all groups of ptype, including the
+// registered groups without members.
+func (auth *AuthModule) groupMembers(
+	ptype string,
+	registered map[string][]string,
+) (map[string][]string, error) {
+	rules, err := auth.enforcer.GetNamedGroupingPolicy(ptype)
+	if err != nil {
+		return nil, &CasbinError{"GetNamedGroupingPolicy", err}
+	}
+
+	groups := make(map[string][]string, len(registered))
+	for group := range registered {
+		groups[group] = nil
+	}
+	for _, rule := range rules {
+		groups[rule[1]] = append(groups[rule[1]], rule[0])
+	}
+
+	return groups, nil
+}
+
+func validateDocument(
+	document PolicyDocument,
+	liveUserGroups, liveResourceGroups map[string][]string,
+	prune bool,
+	protected protections,
+) error {
+	err := validateGroups("userGroup", nullUser, document.UserGroups)
+	if err != nil {
+		return err
+	}
+	err = validateGroups("resourceGroup", nullResource, document.ResourceGroups)
+	if err != nil {
+		return err
+	}
+	for group, resources := range document.ResourceGroups {
+		for _, resource := range resources {
+			// Stored resources may predate pattern validation, only added ones
+			// are validated
+			if slices.Contains(liveResourceGroups[group], resource) {
+				continue
+			}
+			err = ValidateResourcePattern(resource)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = checkNesting(document.UserGroups, liveUserGroups, prune)
+	if err != nil {
+		return err
+	}
+
+	// Groups missing from the document only survive if they are not pruned
+	groupExists := func(
+		groupType GroupType,
+		name string,
+		declared, live map[string][]string,
+	) bool {
+		if _, ok := declared[name]; ok || name == "*" {
+			return true
+		}
+		_, ok := live[name]
+
+		return ok && (!prune || protected.group(groupType, name))
+	}
+
+	// Tenant scoped groups need the admin group of their tenant
+	for _, groups := range []map[string][]string{
+		document.UserGroups,
+		document.ResourceGroups,
+	} {
+		for group := range groups {
+			tenant, _ := SplitTenant(group)
+			if tenant != "" && !groupExists(
+				UserGroupType,
+				TenantAdminGroup(tenant),
+				document.UserGroups,
+				liveUserGroups,
+			) {
+				return &NotFoundError{"tenant", tenant}
+			}
+		}
+	}
+
+	for _, policy := range document.Policies {
+		if policy.UserGroup == "" || policy.ResourceGroup == "" ||
+			policy.Permission == "" {
+			return &ValidationError{
+				fmt.Sprintf("policy %v has empty fields", policy),
+			}
+		}
+		err = validatePolicyEffect(policy)
+		if err != nil {
+			return err
+		}
+		_, err = policy.tenant()
+		if err != nil {
+			return err
+		}
+		if !groupExists(
+			UserGroupType,
+			policy.UserGroup,
+			document.UserGroups,
+			liveUserGroups,
+		) {
+			return &NotFoundError{"userGroup", policy.UserGroup}
+		}
+		if isTenantWildcard(policy.ResourceGroup) {
+			// All resource groups of the tenant are covered
+			tenant, _ := SplitTenant(policy.ResourceGroup)
+			if !groupExists(
+				UserGroupType,
+				TenantAdminGroup(tenant),
+				document.UserGroups,
+				liveUserGroups,
+			) {
+				return &NotFoundError{"tenant", tenant}
+			}
+
+			continue
+		}
+		if !groupExists(
+			ResourceGroupType,
+			policy.ResourceGroup,
+			document.ResourceGroups,
+			liveResourceGroups,
+		) {
+			return &NotFoundError{"resourceGroup", policy.ResourceGroup}
+		}
+	}
+
+	return nil
+}
+
+func validateGroups(
+	groupType, nullName string,
+	groups map[string][]string,
+) error {
+	for group, members := range groups {
+		if group == "" || group == "*" || isTenantWildcard(group) {
+			return &ValidationError{
+				fmt.Sprintf("invalid %s name %q", groupType, group),
+			}
+		}
+		for _, member := range members {
+			if member == "" {
+				return &ValidationError{
+					fmt.Sprintf("%s %s has an empty member", groupType, group),
+				}
+			}
+			if member == nullName {
+				return &ConflictError{fmt.Sprintf("Name %s is reserved", member)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkNesting rejects documents whose user groups would contain each other
+// once applied.
+func checkNesting(declared, live map[string][]string, prune bool) error {
+	members := map[string][]string{}
+	for group, groupMembers := range live {
+		if _, ok := declared[group]; !ok || !prune {
+			members[group] = slices.Clone(groupMembers)
+		}
+	}
+	for group, groupMembers := range declared {
+		members[group] = append(members[group], groupMembers...)
+	}
+
+	// Depth-first search, a group on the current path closes a cycle
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(group string) error
+	visit = func(group string) error {
+		switch state[group] {
+		case onPath:
+			cycle := append(path[slices.Index(path, group):], group)
+
+			return &ConflictError{
+				fmt.Sprintf("User groups form a cycle: %v", cycle),
+			}
+		case done:
+			return nil
+		}
+
+		state[group] = onPath
+		path = append(path, group)
+		for _, member := range members[group] {
+			if _, isGroup := members[member]; !isGroup {
+				continue
+			}
+			err := visit(member)
+			if err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[group] = done
+
+		return nil
+	}
+
+	for _, group := range slices.Sorted(maps.Keys(members)) {
+		err := visit(group)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// groupRegistries are the registry rules of declared and live groups by group
+// name. Declared groups without a rule are registered as created now.
+type groupRegistries struct {
+	declared, live map[string][]string
+}
+
+// diffGroups adds the changes needed to turn the live groups of groupType
+// into the declared ones.
+func (d *PolicyDiff) diffGroups(
+	groupType GroupType,
+	declared, live map[string][]string,
+	registries groupRegistries,
+	prune bool,
+	protected protections,
+) {
+	added, removed := &d.Added.UserGroups, &d.Removed.UserGroups
+	addedMembers := &d.Added.UserMemberships
+	removedMembers := &d.Removed.UserMemberships
+	if groupType == ResourceGroupType {
+		added, removed = &d.Added.ResourceGroups, &d.Removed.ResourceGroups
+		addedMembers = &d.Added.ResourceMemberships
+		removedMembers = &d.Removed.ResourceMemberships
+	}
+	ptype := string(groupType)
+
+	now := time.Now()
+	for _, group := range slices.Sorted(maps.Keys(declared)) {
+		liveMembers, exists := live[group]
+		registration, declaredInfo := registries.declared[group]
+		if !declaredInfo {
+			registration = GroupInfo{Name: group, CreatedAt: now}.rule(groupType)
+		}
+		switch liveRegistration := registries.live[group]; {
+		case !exists:
+			*added = append(*added, group)
+			d.changes = append(d.changes, ruleChange{
+				ptype: groupRegistryType,
+				rule:  registration,
+			})
+		case declaredInfo && !slices.Equal(registration, liveRegistration):
+			// Only the GroupInfo changed, the group is kept
+			d.changes = append(
+				d.changes,
+				ruleChange{
+					ptype:  groupRegistryType,
+					rule:   liveRegistration,
+					remove: true,
+				},
+				ruleChange{ptype: groupRegistryType, rule: registration},
+			)
+		}
+
+		members := slices.Compact(slices.Sorted(slices.Values(declared[group])))
+		for _, member := range members {
+			if slices.Contains(liveMembers, member) {
+				continue
+			}
+			*addedMembers = append(*addedMembers, Membership{group, member})
+			d.changes = append(d.changes, ruleChange{
+				ptype: ptype,
+				rule:  []string{member, group},
+			})
+		}
+
+		if !prune {
+			continue
+		}
+		for _, member := range slices.Sorted(slices.Values(liveMembers)) {
+			if slices.Contains(members, member) ||
+				protected.member(groupType, member, group) {
+				continue
+			}
+			*removedMembers = append(*removedMembers, Membership{group, member})
+			d.changes = append(d.changes, ruleChange{
+				ptype:  ptype,
+				rule:   []string{member, group},
+				remove: true,
+			})
+		}
+	}
+
+	if !prune {
+		return
+	}
+	for _, group := range slices.Sorted(maps.Keys(live)) {
+		if _, ok := declared[group]; ok || protected.group(groupType, group) {
+			continue
+		}
+		*removed = append(*removed, group)
+		for _, member := range live[group] {
+			d.changes = append(d.changes, ruleChange{
+				ptype:  ptype,
+				rule:   []string{member, group},
+				remove: true,
+			})
+		}
+		if registration, ok := registries.live[group]; ok {
+			d.changes = append(d.changes, ruleChange{
+				ptype:  groupRegistryType,
+				rule:   registration,
+				remove: true,
+			})
+		}
+	}
+}
+
+// diffPolicies adds the changes needed to turn the live policies into the
+// declared ones.
+func (d *PolicyDiff) diffPolicies(
+	declared, live []Policy,
+	prune bool,
+	protected protections,
+) {
+	normalized := make([]Policy, 0, len(declared))
+	for _, policy := range declared {
+		normalized = append(normalized, policy.normalize())
+	}
+	declared = normalized
+	slices.SortFunc(declared, comparePolicies)
+	declared = slices.Compact(declared)
+
+	for _, policy := range declared {
+		if slices.Contains(live, policy) {
+			continue
+		}
+		d.Added.Policies = append(d.Added.Policies, policy)
+		d.changes = append(d.changes, ruleChange{
+			ptype: "p",
+			rule:  policy.rule(),
+		})
+	}
+
+	if !prune {
+		return
+	}
+	live = slices.Clone(live)
+	slices.SortFunc(live, comparePolicies)
+	for _, policy := range live {
+		if slices.Contains(declared, policy) || protected.policy(policy) {
+			continue
+		}
+		d.Removed.Policies = append(d.Removed.Policies, policy)
+		d.changes = append(d.changes, ruleChange{
+			ptype:  "p",
+			rule:   policy.rule(),
+			remove: true,
+		})
+	}
+}
+
+func comparePolicies(a, b Policy) int {
+	return cmp.Or(
+		cmp.Compare(a.UserGroup, b.UserGroup),
+		cmp.Compare(a.ResourceGroup, b.ResourceGroup),
+		cmp.Compare(a.Permission, b.Permission),
+		cmp.Compare(a.Effect, b.Effect),
+	)
+}
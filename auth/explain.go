@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"slices"
+	"time"
+)
+
+// PolicyField names one of the three parts of a policy.
+type PolicyField string
+
+const (
+	PolicyFieldUserGroup     PolicyField = "userGroup"
+	PolicyFieldResourceGroup PolicyField = "resourceGroup"
+	PolicyFieldPermission    PolicyField = "permission"
+)
+
+// Explanation describes how an authorization decision was reached.
+type Explanation struct {
+	Allowed  bool
+	User     string
+	Tenant   string
+	Resource string
+	Action   string
+	// UserGroups contains all groups the user is a member of.
+	UserGroups []string
+	// ResourceGroups contains all resource groups the resource belongs to,
+	// either directly, through a KeyMatch2 pattern or through a pattern with
+	// UserPlaceholder resolved to User.
+	ResourceGroups []string
+	// MatchedPolicy is the policy that decided the request: the policy that
+	// allowed it or the deny policy that overrode all allowing ones. It is nil
+	// if no policy matched.
+	MatchedPolicy *Policy
+	// NearMisses contains the allow policies that match all but one part of a
+	// request no policy matched.
+	NearMisses []NearMiss
+}
+
+// NearMiss is a policy that would have allowed a request if the part named by
+// Mismatch had matched.
+type NearMiss struct {
+	Policy   Policy
+	Mismatch PolicyField
+}
+
+// Explain evaluates whether user may perform action on resource and returns
+// the groups and policies that led to the decision. Only policies that apply
+// to all tenants are taken into account, see ExplainInTenant.
+func (auth *AuthModule) Explain(
+	user, resource, action string,
+) (Explanation, error) {
+	return auth.ExplainInTenant("", user, resource, action)
+}
+
+// ExplainInTenant is like Explain for a request made in tenant.
+func (auth *AuthModule) ExplainInTenant(
+	tenant, user, resource, action string,
+) (Explanation, error) {
+	defer auth.rlock()()
+
+	allowed, rawPolicy, err := auth.enforcer.EnforceEx(
+		user,
+		tenant,
+		resource,
+		action,
+	)
+	if err != nil {
+		return Explanation{}, &CasbinError{"EnforceEx", err}
+	}
+
+	now := time.Now()
+	userGroups, err := auth.groupsInEffect(user, now)
+	if err != nil {
+		return Explanation{}, err
+	}
+	slices.Sort(userGroups)
+
+	resourceGroups, err := auth.resourceGroupsFor(user, resource)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	explanation := Explanation{
+		Allowed:        allowed,
+		User:           user,
+		Tenant:         tenant,
+		Resource:       resource,
+		Action:         action,
+		UserGroups:     userGroups,
+		ResourceGroups: resourceGroups,
+		NearMisses:     []NearMiss{},
+	}
+
+	if len(rawPolicy) > 0 {
+		matched := policyFromRule(rawPolicy)
+		explanation.MatchedPolicy = &matched
+
+		return explanation, nil
+	}
+
+	policies, err := auth.listPolicies()
+	if err != nil {
+		return Explanation{}, err
+	}
+	policies, err = auth.policiesInEffect(policies, now)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	for _, policy := range policies {
+		if policy.Effect == EffectDeny || !policy.appliesIn(tenant) {
+			continue
+		}
+
+		mismatches := make([]PolicyField, 0, 3) //nolint:mnd // Policy fields
+		if policy.UserGroup != "*" && policy.UserGroup != user &&
+			!slices.Contains(userGroups, policy.UserGroup) {
+			mismatches = append(mismatches, PolicyFieldUserGroup)
+		}
+		if !policy.coversResourceGroups(resourceGroups) {
+			mismatches = append(mismatches, PolicyFieldResourceGroup)
+		}
+		if !auth.actionCovers(policy.Permission, action) {
+			mismatches = append(mismatches, PolicyFieldPermission)
+		}
+
+		if len(mismatches) == 1 {
+			explanation.NearMisses = append(
+				explanation.NearMisses,
+				NearMiss{policy, mismatches[0]},
+			)
+		}
+	}
+
+	return explanation, nil
+}
+
+// resourceGroupsFor returns the sorted names of all resource groups resource
+// belongs to for requests of user.
+func (auth *AuthModule) resourceGroupsFor(
+	user, resource string,
+) ([]string, error) {
+	groups, err := auth.resourceGroupManager.GetGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []string{}
+	for _, group := range groups {
+		if slices.Contains(matched, group.GroupName) {
+			continue
+		}
+
+		inGroup, err := auth.inResourceGroup(user, resource, group.GroupName)
+		if err != nil {
+			return nil, err
+		}
+		if inGroup {
+			matched = append(matched, group.GroupName)
+		}
+	}
+	slices.Sort(matched)
+
+	return matched, nil
+}
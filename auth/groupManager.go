@@ -2,6 +2,8 @@ package auth
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/casbin/casbin/v3"
 )
@@ -43,6 +45,7 @@ type groupManager[T group] struct {
 	groupType       GroupType
 	groupName       string
 	nullName        string
+	nested          bool // Groups can be members of other groups
 	createGroupFunc func(T, []string) T
 	enforcer        *casbin.Enforcer
 }
@@ -53,6 +56,7 @@ func newUserGroupManager(enforcer *casbin.Enforcer) *groupManager[UserGroup] {
 		groupType: UserGroupType,
 		groupName: "userGroup",
 		nullName:  nullUser,
+		nested:    true,
 		createGroupFunc: func(group UserGroup, data []string) UserGroup {
 			return UserGroup{
 				UserName:  data[0],
@@ -81,73 +85,111 @@ func newResourceGroupManager(
 	}
 }
 
-// CreateGroup creates a new group with the specified name.
-// If the group already exists, the function returns without error.
-func (gm *groupManager[T]) CreateGroup(groupName string) error {
-	groupExists, err := gm.GroupExists(groupName)
+// CreateGroup registers a new group with the metadata info, created now
+// unless info.CreatedAt is set. If the group already exists, its metadata is
+// kept and the function returns without error.
+func (gm *groupManager[T]) CreateGroup(info GroupInfo) (mutation, error) {
+	err := gm.checkName(info.Name)
 	if err != nil {
-		return err
-	}
-
-	if groupExists {
-		return nil
+		return mutation{}, err
 	}
 
-	_, err = gm.enforcer.AddNamedGroupingPolicy(
-		string(gm.groupType),
-		gm.nullName,
-		groupName,
-	)
+	groupExists, err := gm.GroupExists(info.Name)
 	if err != nil {
-		return &CasbinError{"CreateGroup", err}
+		return mutation{}, err
 	}
 
-	err = gm.enforcer.SavePolicy()
-	if err != nil {
-		return &CasbinError{"CreateGroup", err}
+	m := mutation{action: "CreateGroup"}
+	if !groupExists {
+		if info.CreatedAt.IsZero() {
+			info.CreatedAt = time.Now()
+		}
+		m.add(groupRegistryType, info.rule(gm.groupType)...)
 	}
 
-	return nil
+	return m, nil
 }
 
-// RemoveGroup removes a group and all associated policies.
-// It prevents removal of the enclaveAdmin group to maintain system security.
-func (gm *groupManager[T]) RemoveGroup(groupName string) error {
-	if groupName == enclaveAdminGroup {
-		return &ConflictError{"Enclave admin group cannot be removed"}
+// checkName rejects names groups cannot be created or renamed to: empty,
+// reserved, wildcard or pattern names and names scoped to unknown tenants.
+func (gm *groupManager[T]) checkName(name string) error {
+	if name == "" {
+		return &ValidationError{"group name must not be empty"}
+	}
+	if name == gm.nullName {
+		return &ConflictError{fmt.Sprintf("Name %s is reserved", name)}
+	}
+	if isPatternName(name) {
+		return &ValidationError{fmt.Sprintf(
+			"group name %s must not be a wildcard or pattern",
+			name,
+		)}
 	}
 
-	groupExists, err := gm.GroupExists(groupName)
+	return checkTenant(gm.enforcer, name)
+}
+
+// RemoveGroup removes a group and all associated policies.
+func (gm *groupManager[T]) RemoveGroup(groupName string) (mutation, error) {
+	registration, err := gm.registration(groupName)
 	if err != nil {
-		return err
+		return mutation{}, err
 	}
-	if !groupExists {
-		return &NotFoundError{gm.groupName, groupName}
+	if registration == nil {
+		return mutation{}, &NotFoundError{gm.groupName, groupName}
 	}
 
-	_, err = gm.enforcer.RemoveFilteredNamedGroupingPolicy(
+	members, err := gm.enforcer.GetFilteredNamedGroupingPolicy(
 		string(gm.groupType),
 		1,
 		groupName,
 	)
 	if err != nil {
-		return &CasbinError{"RemoveGroup", err}
+		return mutation{}, &CasbinError{"RemoveGroup", err}
 	}
 
-	_, err = gm.enforcer.RemoveFilteredPolicy(0, groupName)
-	if err != nil {
-		return &CasbinError{"RemoveGroup", err}
+	if gm.nested {
+		// Nested groups leave the groups they are a member of
+		memberships, err := gm.enforcer.GetFilteredNamedGroupingPolicy(
+			string(gm.groupType),
+			0,
+			groupName,
+		)
+		if err != nil {
+			return mutation{}, &CasbinError{"RemoveGroup", err}
+		}
+		members = append(members, memberships...)
 	}
 
-	err = gm.enforcer.SavePolicy()
+	policies, err := gm.enforcer.GetFilteredPolicy(gm.policyField(), groupName)
 	if err != nil {
-		return &CasbinError{"RemoveGroup", err}
+		return mutation{}, &CasbinError{"RemoveGroup", err}
 	}
 
-	return nil
+	m := mutation{action: "RemoveGroup"}
+	for _, member := range members {
+		m.remove(string(gm.groupType), member...)
+	}
+	for _, policy := range policies {
+		m.remove("p", policy...)
+	}
+	m.remove(groupRegistryType, registration...)
+
+	return m, nil
+}
+
+// policyField is the field of policies naming groups of this manager, user
+// groups are the subject and resource groups the object.
+func (gm *groupManager[T]) policyField() int {
+	if gm.groupType == ResourceGroupType {
+		return 1
+	}
+
+	return 0
 }
 
-// GetGroups returns all groups as a slice of group structs.
+// GetGroups returns the members of all groups as a slice of group structs.
+// Groups without members are not included, see GroupNames.
 func (gm *groupManager[T]) GetGroups() ([]T, error) {
 	groups, err := gm.enforcer.GetNamedGroupingPolicy(string(gm.groupType))
 	if err != nil {
@@ -165,16 +207,9 @@ func (gm *groupManager[T]) GetGroups() ([]T, error) {
 
 // GroupExists checks if a group with the specified name exists.
 func (gm *groupManager[T]) GroupExists(groupName string) (bool, error) {
-	filtered, err := gm.enforcer.GetFilteredNamedGroupingPolicy(
-		string(gm.groupType),
-		1,
-		groupName,
-	)
-	if err != nil {
-		return false, &CasbinError{"GroupExists", err}
-	}
+	registration, err := gm.registration(groupName)
 
-	return len(filtered) > 0, nil
+	return registration != nil, err
 }
 
 // AddToGroup adds an entity to one or more groups.
@@ -182,54 +217,55 @@ func (gm *groupManager[T]) GroupExists(groupName string) (bool, error) {
 func (gm *groupManager[T]) AddToGroup(
 	entityName string,
 	groupName ...string,
-) error {
-	if entityName == gm.nullName {
-		return &ConflictError{fmt.Sprintf("Name %s is reserved", entityName)}
+) (mutation, error) {
+	err := gm.checkMembership(entityName, groupName)
+	if err != nil {
+		return mutation{}, err
 	}
 
-	// Check that all groups exist
+	m := mutation{action: "AddToGroup"}
 	for _, group := range groupName {
-		groupExists, err := gm.GroupExists(group)
-		if err != nil {
-			return err
-		}
-		if !groupExists {
-			return &NotFoundError{gm.groupName, group}
+		if gm.nested {
+			err = gm.checkCycle(entityName, group)
+			if err != nil {
+				return mutation{}, err
+			}
 		}
+		m.add(string(gm.groupType), entityName, group)
 	}
 
-	groupingPolicies := make([][]string, len(groupName))
-	for i, group := range groupName {
-		groupingPolicies[i] = []string{entityName, group}
-	}
+	return m, nil
+}
 
-	_, err := gm.enforcer.AddNamedGroupingPolicies(
-		string(gm.groupType),
-		groupingPolicies,
-	)
+// RemoveFromGroup removes an entity from one or more groups.
+// It validates that all specified groups exist before removing the entity.
+func (gm *groupManager[T]) RemoveFromGroup(
+	entityName string,
+	groupName ...string,
+) (mutation, error) {
+	err := gm.checkMembership(entityName, groupName)
 	if err != nil {
-		return &CasbinError{"AddToGroup", err}
+		return mutation{}, err
 	}
 
-	err = gm.enforcer.SavePolicy()
-	if err != nil {
-		return &CasbinError{"AddToGroup", err}
+	m := mutation{action: "RemoveFromGroup"}
+	for _, group := range groupName {
+		m.remove(string(gm.groupType), entityName, group)
 	}
 
-	return nil
+	return m, nil
 }
 
-// RemoveFromGroup removes an entity from one or more groups.
-// It validates that all specified groups exist before removing the entity.
-func (gm *groupManager[T]) RemoveFromGroup(
+// checkMembership validates that entityName is not reserved and all groups
+// exist.
+func (gm *groupManager[T]) checkMembership(
 	entityName string,
-	groupName ...string,
+	groupName []string,
 ) error {
 	if entityName == gm.nullName {
 		return &ConflictError{fmt.Sprintf("Name %s is reserved", entityName)}
 	}
 
-	// Check that all groups exist
 	for _, group := range groupName {
 		groupExists, err := gm.GroupExists(group)
 		if err != nil {
@@ -240,48 +276,32 @@ func (gm *groupManager[T]) RemoveFromGroup(
 		}
 	}
 
-	groupingPolicies := make([][]string, len(groupName))
-	for i, group := range groupName {
-		groupingPolicies[i] = []string{entityName, group}
-	}
-
-	_, err := gm.enforcer.RemoveNamedGroupingPolicies(
-		string(gm.groupType),
-		groupingPolicies,
-	)
-	if err != nil {
-		return &CasbinError{"RemoveFromGroup", err}
-	}
-
-	err = gm.enforcer.SavePolicy()
-	if err != nil {
-		return &CasbinError{"RemoveFromGroup", err}
-	}
-
 	return nil
 }
 
 // RemoveEntity removes an entity from all groups it belongs to.
-func (gm *groupManager[T]) RemoveEntity(entityName string) error {
+func (gm *groupManager[T]) RemoveEntity(entityName string) (mutation, error) {
 	if entityName == gm.nullName {
-		return &ConflictError{fmt.Sprintf("Name %s is reserved", entityName)}
+		return mutation{}, &ConflictError{
+			fmt.Sprintf("Name %s is reserved", entityName),
+		}
 	}
 
-	_, err := gm.enforcer.RemoveFilteredNamedGroupingPolicy(
+	memberships, err := gm.enforcer.GetFilteredNamedGroupingPolicy(
 		string(gm.groupType),
 		0,
 		entityName,
 	)
 	if err != nil {
-		return &CasbinError{"RemoveEntity", err}
+		return mutation{}, &CasbinError{"RemoveEntity", err}
 	}
 
-	err = gm.enforcer.SavePolicy()
-	if err != nil {
-		return &CasbinError{"RemoveEntity", err}
+	m := mutation{action: "RemoveEntity"}
+	for _, membership := range memberships {
+		m.remove(string(gm.groupType), membership...)
 	}
 
-	return nil
+	return m, nil
 }
 
 // GetGroupsForEntity returns all groups that a specific entity belongs to.
@@ -328,11 +348,104 @@ func (gm *groupManager[T]) GetEntitiesInGroup(
 
 	entityNames := make([]string, 0, len(entityGroups))
 	for _, group := range entityGroups {
-		if group[0] == gm.nullName {
-			continue
-		}
 		entityNames = append(entityNames, group[0])
 	}
 
 	return entityNames, nil
 }
+
+// checkCycle rejects adding entityName to groupName if groupName already is,
+// directly or through nested groups, a member of entityName.
+func (gm *groupManager[T]) checkCycle(entityName, groupName string) error {
+	if entityName == groupName {
+		return &ConflictError{
+			fmt.Sprintf("Group %s cannot be a member of itself", groupName),
+		}
+	}
+
+	direct, err := gm.GetGroupsForEntity(groupName)
+	if err != nil {
+		return err
+	}
+	inherited, err := gm.walk(direct, gm.GetGroupsForEntity)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(direct, entityName) ||
+		slices.Contains(inherited, entityName) {
+		return &ConflictError{fmt.Sprintf(
+			"Adding %s to %s would create a cycle, %s is a member of %s",
+			entityName,
+			groupName,
+			groupName,
+			entityName,
+		)}
+	}
+
+	return nil
+}
+
+// ancestors returns all groups that entityName belongs to through nested
+// groups, excluding its direct groups.
+func (gm *groupManager[T]) ancestors(entityName string) ([]string, error) {
+	direct, err := gm.GetGroupsForEntity(entityName)
+	if err != nil {
+		return nil, err
+	}
+
+	return gm.walk(direct, gm.GetGroupsForEntity)
+}
+
+// descendants returns all members of groupName's nested groups that are not
+// direct members of groupName.
+func (gm *groupManager[T]) descendants(groupName string) ([]string, error) {
+	direct, err := gm.GetEntitiesInGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	return gm.walk(direct, func(member string) ([]string, error) {
+		isGroup, err := gm.GroupExists(member)
+		if err != nil || !isGroup {
+			return nil, err
+		}
+
+		return gm.GetEntitiesInGroup(member)
+	})
+}
+
+// walk follows next from the direct names and returns all names reached that
+// are not direct, sorted. Names are visited once, so cycles in stored rules
+// cannot make it loop.
+func (gm *groupManager[T]) walk(
+	direct []string,
+	next func(string) ([]string, error),
+) ([]string, error) {
+	visited := map[string]bool{}
+	for _, name := range direct {
+		visited[name] = true
+	}
+
+	queue := slices.Clone(direct)
+	var reached []string
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		names, err := next(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			reached = append(reached, name)
+			queue = append(queue, name)
+		}
+	}
+	slices.Sort(reached)
+
+	return reached, nil
+}
@@ -1,41 +1,146 @@
 package auth
 
 import (
+	"context"
 	"slices"
+	"sync"
+	"time"
 
+	"github.com/EnclaveRunner/shareddeps/config"
 	"github.com/casbin/casbin/v3"
 	"github.com/casbin/casbin/v3/model"
 	"github.com/casbin/casbin/v3/persist"
-	"github.com/casbin/casbin/v3/util"
 	"github.com/rs/zerolog/log"
 )
 
+// AuthModule is safe for concurrent use. Reads share a lock, mutations and
+// transactions hold it exclusively. Copies of a module share all state and
+// can be used interchangeably.
 type AuthModule struct {
+	mu                   *sync.RWMutex
 	enforcer             *casbin.Enforcer
 	resourceGroupManager *groupManager[ResourceGroup]
 	userGroupManager     *groupManager[UserGroup]
+	persister            *persister
+	explainDenials       bool
+	defaults             *PolicyDocument
+	reloadPath           string
+	tenantResolver       TenantResolver
+	actionSets           ActionSets
+	grpcActions          map[string]string
+	sweepInterval        time.Duration
+	lifecycle            *lifecycle
+	protections          *protections
+	decisions            *decisions
+	// tx is only set on the copy a transaction runs on, see Update.
+	tx *Tx
+}
+
+// lifecycle is the state of the background work of a module. It is shared by
+// all copies of the module, so any of them can be closed.
+type lifecycle struct {
+	mu          sync.Mutex
+	watcher     persist.Watcher
+	reloader    *policyFile
+	stopSweeper context.CancelFunc
+	closed      bool
+}
+
+// Option configures optional behavior of the AuthModule created by NewModule.
+type Option func(*AuthModule)
+
+// WithDenialExplanations makes the Middleware log an Explanation at debug
+// level for every denied request.
+func WithDenialExplanations() Option {
+	return func(auth *AuthModule) {
+		auth.explainDenials = true
+	}
+}
+
+// WithDefaults makes NewModule apply cfg on startup, see ApplyDefaults.
+func WithDefaults(cfg config.AuthConfig) Option {
+	return func(auth *AuthModule) {
+		document := defaultsDocument(cfg)
+		auth.defaults = &document
+	}
+}
+
+// ApplyDefaults applies the groups, resources and policies of the auth section
+// of the service config. Entries that already exist are left untouched and
+// nothing missing from the config is removed, so changes made at runtime
+// survive restarts and applying the same config again changes nothing.
+func (auth *AuthModule) ApplyDefaults(cfg config.AuthConfig) error {
+	return auth.applyDefaults(defaultsDocument(cfg))
+}
+
+func (auth *AuthModule) applyDefaults(document PolicyDocument) error {
+	diff, err := auth.ApplyDocument(document, ApplyOptions{})
+	if err != nil {
+		return err
+	}
+	log.Debug().
+		Int("userGroups", len(diff.Added.UserGroups)).
+		Int("resourceGroups", len(diff.Added.ResourceGroups)).
+		Int("policies", len(diff.Added.Policies)).
+		Msg("Applied default auth config")
+
+	return nil
+}
+
+// defaultsDocument converts the auth section of the service config to a
+// PolicyDocument.
+func defaultsDocument(cfg config.AuthConfig) PolicyDocument {
+	document := PolicyDocument{
+		UserGroups:     map[string][]string{},
+		ResourceGroups: map[string][]string{},
+	}
+	for _, group := range cfg.UserGroups {
+		document.UserGroups[group.Name] = append(
+			document.UserGroups[group.Name],
+			group.Members...,
+		)
+	}
+	for _, group := range cfg.ResourceGroups {
+		document.ResourceGroups[group.Name] = append(
+			document.ResourceGroups[group.Name],
+			group.Members...,
+		)
+	}
+	for _, policy := range cfg.Policies {
+		document.Policies = append(document.Policies, Policy{
+			UserGroup:     policy.UserGroup,
+			ResourceGroup: policy.ResourceGroup,
+			Permission:    policy.Permission,
+			Effect:        Effect(policy.Effect),
+		})
+	}
+
+	return document
 }
 
 // NewModule initializes the casbin enforcer with the provided adapter and sets
 // up default policies. It creates the casbin model, loads policies, and ensures
 // the enclaveAdmin group and policy exist.
-func NewModule(adapter persist.Adapter) AuthModule {
+func NewModule(adapter persist.Adapter, opts ...Option) AuthModule {
 	modelContent := `
 		[request_definition]
-		r = sub, obj, act
+		r = sub, dom, obj, act
 
 		[policy_definition]
-		p = sub, obj, act
+		p = sub, obj, act, eft
+		p2 = sub, obj, act, eft, nbf, exp
+		p3 = sub, grp, nbf, exp
+		p4 = type, name, desc, owner, created, creator
 
 		[role_definition]
 		g = _, _
 		g2 = _, _
 
 		[policy_effect]
-		e = some(where (p.eft == allow))
+		e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
 
 		[matchers]
-		m = (g(r.sub, p.sub) || p.sub == "*") && (g2(r.obj, p.obj) || p.obj == "*") && (r.act == p.act || p.act == "*")
+		m = (memberOf(r.sub, p.sub) || p.sub == "*") && (g2(r.obj, p.obj) || p.obj == "*" || ownedBy(r.sub, r.obj, p.obj)) && (r.act == p.act || p.act == "*" || inActionSet(r.act, p.act)) && inTenant(r.dom, p.sub, p.obj) && inEffect(p.sub, p.obj, p.act, p.eft)
 	`
 
 	m, err := model.NewModelFromString(modelContent)
@@ -43,22 +148,50 @@ func NewModule(adapter persist.Adapter) AuthModule {
 		log.Fatal().Err(err).Msg("Failed to create casbin model from string")
 	}
 
-	enforcer, err := casbin.NewEnforcer(m, adapter)
+	// The adapter is set afterwards, policies are loaded below
+	enforcer, err := casbin.NewEnforcer(m)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create casbin enforcer")
 	}
+	enforcer.SetAdapter(adapter)
+
+	// Changes are persisted on commit of a transaction, see persister
+	enforcer.EnableAutoSave(false)
 
-	// Add KeyMatch2 function for resource group matching
+	// Add KeyMatch2 style function for resource group matching
 	// Endpoints can now contain ":name" for patterns. E.g.: /v1/user/:id
-	// See https://casbin.apache.org/de/docs/rbac-with-pattern for more info
-	ok := enforcer.AddNamedMatchingFunc("g2", "KeyMatch2", util.KeyMatch2)
+	// See ValidateResourcePattern for the full syntax
+	ok := enforcer.AddNamedMatchingFunc("g2", "KeyMatch2", keyMatch)
 	if !ok {
 		log.Fatal().Msg("Failed to add KeyMatch2 function")
 	}
 
+	// Resource patterns containing "$user" only match for the user it resolves
+	// to. E.g.: /v1/users/$user/*
+	enforcer.AddFunction("ownedBy", ownedByFunc(enforcer))
+
+	// Policies of tenant scoped groups only apply to requests of their tenant
+	enforcer.AddFunction("inTenant", inTenantFunc)
+	// Time bounds are judged at decision time, the sweeper only removes
+	// expired rules, see WithTimeBoundSweeper
+	decisions := newDecisions(enforcer)
+	enforcer.AddFunction("memberOf", memberOfFunc(decisions))
+	enforcer.AddFunction("inEffect", inEffectFunc(decisions))
+
 	err = enforcer.LoadPolicy()
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to load casbin policy")
+		// Policies stored before effects existed fail to load
+		migrated, migrateErr := migratePolicyEffects(adapter, m)
+		if migrateErr != nil {
+			log.Fatal().Err(migrateErr).Msg("Failed to migrate casbin policy")
+		}
+		if !migrated {
+			log.Fatal().Err(err).Msg("Failed to load casbin policy")
+		}
+		err = enforcer.LoadPolicy()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load migrated casbin policy")
+		}
 	}
 
 	policies, err := enforcer.GetPolicy()
@@ -69,37 +202,54 @@ func NewModule(adapter persist.Adapter) AuthModule {
 	containsAdminPolicy := slices.IndexFunc(
 		policies,
 		func(policy []string) bool {
-			return policy[0] == enclaveAdminGroup && policy[1] == "*" &&
-				policy[2] == "*"
+			return policyFromRule(policy) == adminPolicy
 		},
 	) != -1
 
 	if !containsAdminPolicy {
-		_, err = enforcer.AddPolicy(enclaveAdminGroup, "*", "*")
+		_, err = enforcer.AddPolicy(adminPolicy.rule())
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to add enclave_admin casbin policy")
 		}
 		log.Info().Msg("Added enclave_admin casbin policy")
 	}
 
-	userGroups, err := enforcer.GetNamedGroupingPolicy("g")
+	// Groups used to be marked by placeholder members, see GroupInfo
+	err = migrateGroupRegistry(enforcer)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get casbin user groups")
+		log.Fatal().Err(err).Msg("Failed to migrate casbin groups to the registry")
 	}
 
-	containsAdminGroup := slices.IndexFunc(
-		userGroups,
-		func(group []string) bool {
-			return group[1] == enclaveAdminGroup
-		},
-	) != -1
+	// Time-bound rules used to be added only once their NotBefore passed
+	err = migratePendingTimeBounds(enforcer)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to migrate casbin time bounds")
+	}
+
+	// Tenant admin policies used to cover global resources as well
+	err = migrateTenantAdminPolicies(enforcer)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to migrate tenant admin casbin policies")
+	}
+
+	adminGroup, err := enforcer.GetFilteredNamedPolicy(
+		groupRegistryType,
+		0,
+		string(UserGroupType),
+		enclaveAdminGroup,
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to get enclave_admin casbin group")
+	}
 
-	if !containsAdminGroup {
-		_, err = enforcer.AddNamedGroupingPolicy("g", nullUser, enclaveAdminGroup)
+	if len(adminGroup) == 0 {
+		_, err = enforcer.AddNamedPolicy(
+			groupRegistryType,
+			GroupInfo{Name: enclaveAdminGroup, CreatedAt: time.Now()}.
+				rule(UserGroupType),
+		)
 		if err != nil {
-			log.Fatal().
-				Err(err).
-				Msg("Failed to add admin to enclave_admin casbin group")
+			log.Fatal().Err(err).Msg("Failed to add enclave_admin casbin group")
 		}
 	}
 
@@ -110,9 +260,51 @@ func NewModule(adapter persist.Adapter) AuthModule {
 
 	log.Debug().Msg("Casbin enforcer initialized")
 
-	return AuthModule{
+	authModule := AuthModule{
+		mu:                   &sync.RWMutex{},
 		enforcer:             enforcer,
 		resourceGroupManager: newResourceGroupManager(enforcer),
 		userGroupManager:     newUserGroupManager(enforcer),
+		persister:            newPersister(adapter),
+		actionSets:           ActionSets{},
+		lifecycle:            &lifecycle{},
+		protections:          &protections{enclaveAdminProtection},
+		decisions:            decisions,
+	}
+	for _, opt := range opts {
+		opt(&authModule)
+	}
+
+	// Policies can name an action set instead of a single method
+	authModule.actionSets = authModule.actionSets.resolve()
+	enforcer.AddFunction("inActionSet", inActionSetFunc(authModule.actionSets))
+
+	if authModule.lifecycle.watcher != nil {
+		err = authModule.lifecycle.watcher.SetUpdateCallback(func(string) {
+			authModule.reload()
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to set casbin watcher callback")
+		}
+	}
+
+	if authModule.defaults != nil {
+		err = authModule.applyDefaults(*authModule.defaults)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to apply default auth config")
+		}
 	}
+
+	if authModule.reloadPath != "" {
+		err = authModule.watchPolicyFile(authModule.reloadPath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to watch casbin policy file")
+		}
+	}
+
+	if authModule.sweepInterval > 0 {
+		authModule.startSweeper()
+	}
+
+	return authModule
 }
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"cmp"
+	"encoding/base64"
+	"slices"
+	"strings"
+)
+
+// ListOptions filters and paginates a listing. The zero value lists all
+// entries. Entries are always sorted, so pages stay stable while entries are
+// added or removed between requests.
+type ListOptions struct {
+	// Prefix keeps only entries with a name starting with Prefix.
+	Prefix string
+	// Contains keeps only entries with a name containing Contains.
+	Contains string
+	// Limit is the maximum number of entries of a page, 0 for no limit.
+	Limit int
+	// Cursor continues a listing after the page that returned it as
+	// Page.NextCursor.
+	Cursor string
+}
+
+// Page is a page of a listing.
+type Page[T any] struct {
+	Items []T
+	// NextCursor continues the listing with the next page, see
+	// ListOptions.Cursor. It is empty on the last page.
+	NextCursor string
+}
+
+// ListUserGroupsPage returns a page of the sorted user group names, filtered
+// by group name.
+func (auth *AuthModule) ListUserGroupsPage(
+	opts ListOptions,
+) (Page[string], error) {
+	defer auth.rlock()()
+
+	names, err := auth.userGroupManager.GroupNames()
+	if err != nil {
+		return Page[string]{}, err
+	}
+
+	return paginate(names, nameKey, opts)
+}
+
+// ListResourceGroupsPage returns a page of the sorted resource group names,
+// filtered by group name.
+func (auth *AuthModule) ListResourceGroupsPage(
+	opts ListOptions,
+) (Page[string], error) {
+	defer auth.rlock()()
+
+	names, err := auth.resourceGroupManager.GroupNames()
+	if err != nil {
+		return Page[string]{}, err
+	}
+
+	return paginate(names, nameKey, opts)
+}
+
+// GetUserGroupPage returns a page of the sorted users and user groups that
+// directly belong to groupName, filtered by their name.
+func (auth *AuthModule) GetUserGroupPage(
+	groupName string,
+	opts ListOptions,
+) (Page[string], error) {
+	defer auth.rlock()()
+
+	members, err := auth.userGroupManager.GetEntitiesInGroup(groupName)
+	if err != nil {
+		return Page[string]{}, err
+	}
+
+	return paginate(members, nameKey, opts)
+}
+
+// GetResourceGroupPage returns a page of the sorted resources that belong to
+// groupName, filtered by resource.
+func (auth *AuthModule) GetResourceGroupPage(
+	groupName string,
+	opts ListOptions,
+) (Page[string], error) {
+	defer auth.rlock()()
+
+	resources, err := auth.resourceGroupManager.GetEntitiesInGroup(groupName)
+	if err != nil {
+		return Page[string]{}, err
+	}
+
+	return paginate(resources, nameKey, opts)
+}
+
+// ListPoliciesPage returns a page of the policies sorted by user group,
+// resource group, permission and effect. Time-bound policies are included
+// with their Validity, even if they are not in effect yet, all others have a
+// zero Validity. Filters match the user group or the resource group of a
+// policy.
+func (auth *AuthModule) ListPoliciesPage(
+	opts ListOptions,
+) (Page[TimeBoundPolicy], error) {
+	defer auth.rlock()()
+
+	policies, err := auth.listPolicies()
+	if err != nil {
+		return Page[TimeBoundPolicy]{}, err
+	}
+	timeBound, err := auth.listTimeBoundPolicies()
+	if err != nil {
+		return Page[TimeBoundPolicy]{}, err
+	}
+
+	// Time-bound policies in effect are listed once, with their validity
+	listed := timeBound
+	bounded := make(map[Policy]bool, len(timeBound))
+	for _, policy := range timeBound {
+		bounded[policy.Policy] = true
+	}
+	for _, policy := range policies {
+		if !bounded[policy] {
+			listed = append(listed, TimeBoundPolicy{Policy: policy})
+		}
+	}
+
+	return paginate(listed, func(policy TimeBoundPolicy) (string, []string) {
+		return policyKey(policy.Policy)
+	}, opts)
+}
+
+// listKey returns the sort key of an entry and the names filters match.
+type listKey[T any] func(T) (string, []string)
+
+func nameKey(name string) (string, []string) {
+	return name, []string{name}
+}
+
+func policyKey(policy Policy) (string, []string) {
+	// The separator sorts before all printable characters
+	key := strings.Join(policy.rule(), "\x00")
+
+	return key, []string{policy.UserGroup, policy.ResourceGroup}
+}
+
+// paginate sorts items by key, filters them by name and returns the page
+// opts selects.
+func paginate[T any](
+	items []T,
+	key listKey[T],
+	opts ListOptions,
+) (Page[T], error) {
+	if opts.Limit < 0 {
+		return Page[T]{}, &ValidationError{"limit must not be negative"}
+	}
+	after, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	type entry struct {
+		item T
+		key  string
+	}
+	entries := make([]entry, 0, len(items))
+	for _, item := range items {
+		itemKey, names := key(item)
+		if opts.Cursor != "" && itemKey <= after {
+			continue
+		}
+		if !slices.ContainsFunc(names, opts.matches) {
+			continue
+		}
+		entries = append(entries, entry{item, itemKey})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		return cmp.Compare(a.key, b.key)
+	})
+
+	page := Page[T]{Items: make([]T, 0, len(entries))}
+	for i, entry := range entries {
+		if opts.Limit > 0 && i == opts.Limit {
+			page.NextCursor = encodeCursor(entries[i-1].key)
+
+			break
+		}
+		page.Items = append(page.Items, entry.item)
+	}
+
+	return page, nil
+}
+
+// matches reports whether name passes the prefix and substring filters.
+func (opts ListOptions) matches(name string) bool {
+	return strings.HasPrefix(name, opts.Prefix) &&
+		strings.Contains(name, opts.Contains)
+}
+
+// encodeCursor returns the opaque cursor of a page ending with key.
+func encodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// decodeCursor returns the key of the last entry before cursor.
+func decodeCursor(cursor string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", &ValidationError{"invalid cursor"}
+	}
+
+	return string(key), nil
+}
+
+// sortMembers sorts the members of groups by group and member.
+func sortMembers[T group](groups []T) []T {
+	slices.SortFunc(groups, func(a, b T) int {
+		return cmp.Or(
+			cmp.Compare(a.GetGroupName(), b.GetGroupName()),
+			cmp.Compare(a.GetName(), b.GetName()),
+		)
+	})
+
+	return groups
+}
@@ -0,0 +1,317 @@
+package auth
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// UserPlaceholder is a resource pattern segment that matches the name of the
+// user making a request, e.g. "/v1/users/$user/*". It lets policies grant users
+// access to their own resources only.
+const UserPlaceholder = "$user"
+
+// keyMatchCacheSize is the number of compiled patterns keyMatchCache holds.
+// Casbin's role manager passes request paths as patterns too, so the cache
+// evicts the least recently used patterns instead of growing with every path
+// requested.
+const keyMatchCacheSize = 4096
+
+// keyMatchCache holds the most recently used compiled patterns.
+var keyMatchCache = newLRUCache[*compiledPattern](keyMatchCacheSize)
+
+// lruCache is a least recently used cache of values keyed by strings.
+type lruCache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Front is the most recently used *cacheEntry
+	entries map[string]*list.Element
+}
+
+type cacheEntry[V any] struct {
+	key   string
+	value V
+}
+
+func newLRUCache[V any](size int) *lruCache[V] {
+	return &lruCache[V]{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *lruCache[V]) load(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+
+	//nolint:forcetypeassert // The list only holds cache entries
+	return element.Value.(*cacheEntry[V]).value, true
+}
+
+// store adds or replaces the value of key and evicts the least recently used
+// entry if the cache is full.
+func (c *lruCache[V]) store(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		//nolint:forcetypeassert // The list only holds cache entries
+		element.Value.(*cacheEntry[V]).value = value
+		c.order.MoveToFront(element)
+
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry[V]{key, value})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		//nolint:forcetypeassert // The list only holds cache entries
+		delete(c.entries, oldest.Value.(*cacheEntry[V]).key)
+	}
+}
+
+// paramName is the syntax of path parameter names.
+var paramName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// compiledPattern is a resource pattern compiled to a regular expression.
+type compiledPattern struct {
+	re *regexp.Regexp
+	// captures describes the capture groups of re, one per UserPlaceholder
+	// segment or parameter with a regex constraint.
+	captures []capture
+	owned    bool
+	// err is the first syntax error of the pattern, see ValidateResourcePattern.
+	err error
+}
+
+// capture is a path segment whose value is checked after matching: it has to
+// match constraint or, if owner is set, be the same for all owner captures.
+type capture struct {
+	owner      bool
+	constraint *regexp.Regexp
+}
+
+// ValidateResourcePattern returns a ValidationError if pattern is not a valid
+// resource pattern. Patterns are split into segments at "/":
+//
+//   - ":name" and "{name}" match exactly one segment.
+//   - "{name:regex}" matches one segment the regular expression matches as a
+//     whole, e.g. "{id:[0-9]+}".
+//   - UserPlaceholder matches the segment that is the requesting user's name.
+//   - A trailing "*" matches the rest of the resource, e.g. "/v1/*" or
+//     "/v1/run*". It is not supported anywhere else.
+//   - Everything else is compared literally, so names like "artifact:123"
+//     only match themselves.
+//
+// Paths must not contain empty segments, which also rejects trailing slashes
+// like in "/v1/users/:id/".
+func ValidateResourcePattern(pattern string) error {
+	return compilePattern(pattern).err
+}
+
+// keyMatch reports whether key matches the resource pattern, see
+// ValidateResourcePattern for the syntax. Patterns containing UserPlaceholder
+// never match here, they depend on the requesting user, see resourceOwner.
+func keyMatch(key, pattern string) bool {
+	compiled := compilePattern(pattern)
+	if compiled.owned {
+		return false
+	}
+
+	_, ok := compiled.match(key)
+
+	return ok
+}
+
+// resourceOwner returns the user owning key according to pattern: the path
+// segment all UserPlaceholder segments of pattern match. It returns false if
+// pattern has no placeholder or key does not match it.
+func resourceOwner(key, pattern string) (string, bool) {
+	if !strings.Contains(pattern, UserPlaceholder) {
+		return "", false
+	}
+
+	compiled := compilePattern(pattern)
+	if !compiled.owned {
+		return "", false
+	}
+
+	return compiled.match(key)
+}
+
+// match reports whether key matches the pattern and returns the value of its
+// UserPlaceholder segments, if any.
+func (c *compiledPattern) match(key string) (string, bool) {
+	if len(c.captures) == 0 {
+		return "", c.re.MatchString(key)
+	}
+
+	values := c.re.FindStringSubmatch(key)
+	if values == nil {
+		return "", false
+	}
+
+	owner := ""
+	for i, capture := range c.captures {
+		value := values[i+1]
+		switch {
+		case capture.owner && owner != "" && value != owner:
+			return "", false
+		case capture.owner:
+			owner = value
+		case !capture.constraint.MatchString(value):
+			return "", false
+		}
+	}
+
+	return owner, true
+}
+
+// resolveOwner replaces the UserPlaceholder segments of pattern with user. It
+// returns false if user cannot own resources, see canOwn.
+func resolveOwner(pattern, user string) (string, bool) {
+	if !canOwn(user) {
+		return "", false
+	}
+
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if segment == UserPlaceholder {
+			segments[i] = user
+		}
+	}
+
+	return strings.Join(segments, "/"), true
+}
+
+// canOwn reports whether user can own resources. Unauthenticated requests own
+// nothing and names spanning several path segments never match a placeholder.
+func canOwn(user string) bool {
+	return user != "" && user != UnauthenticatedUser &&
+		!strings.Contains(user, "/")
+}
+
+// compilePattern returns the cached compiled pattern, compiling it if it is
+// not cached. Invalid patterns are compiled as well as possible, so resources
+// stored
+// before patterns were validated keep matching: unsupported syntax is compared
+// literally and a "*" segment matches any rest like in casbin's KeyMatch2.
+func compilePattern(pattern string) *compiledPattern {
+	if cached, ok := keyMatchCache.load(pattern); ok {
+		return cached
+	}
+
+	compiled := &compiledPattern{}
+	invalid := func(format string, args ...any) {
+		if compiled.err == nil {
+			compiled.err = &ValidationError{fmt.Sprintf(
+				"resource pattern %q: %s",
+				pattern,
+				fmt.Sprintf(format, args...),
+			)}
+		}
+	}
+
+	if pattern == "" {
+		invalid("must not be empty")
+	}
+
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch {
+		case segment == "":
+			// Only the leading slash and the root path "/" are empty
+			if i > 0 && pattern != "/" {
+				invalid("empty path segment")
+			}
+		case segment == UserPlaceholder:
+			segments[i] = "([^/]+)"
+			compiled.captures = append(compiled.captures, capture{owner: true})
+			compiled.owned = true
+		case segment == "*":
+			if !last {
+				invalid("* is only supported at the end of a pattern")
+			}
+			segments[i] = ".*"
+		case segment[0] == ':':
+			segments[i] = compiled.param(segment[1:], segment, invalid)
+		case segment[0] == '{' && segment[len(segment)-1] == '}':
+			segments[i] = compiled.param(
+				segment[1:len(segment)-1],
+				segment,
+				invalid,
+			)
+		default:
+			segments[i] = literal(segment, last, invalid)
+		}
+	}
+
+	compiled.re = regexp.MustCompile("^" + strings.Join(segments, "/") + "$")
+	keyMatchCache.store(pattern, compiled)
+
+	return compiled
+}
+
+// param returns the expression of a path parameter segment. definition is the
+// name of the parameter, optionally followed by ":" and a regex constraint.
+func (c *compiledPattern) param(
+	definition, segment string,
+	invalid func(format string, args ...any),
+) string {
+	name, constraint, constrained := strings.Cut(definition, ":")
+	if !paramName.MatchString(name) {
+		invalid("invalid parameter name %q", name)
+
+		return regexp.QuoteMeta(segment)
+	}
+	if !constrained {
+		return "[^/]+"
+	}
+
+	re, err := regexp.Compile("^(?:" + constraint + ")$")
+	if err != nil {
+		invalid("invalid constraint of parameter %s: %v", name, err)
+
+		return regexp.QuoteMeta(segment)
+	}
+	c.captures = append(c.captures, capture{constraint: re})
+
+	return "([^/]+)"
+}
+
+// literal returns the expression of a segment that is compared literally,
+// except for a trailing "*" glob in the last segment.
+func literal(
+	segment string,
+	last bool,
+	invalid func(format string, args ...any),
+) string {
+	if strings.ContainsAny(segment, "{}") {
+		invalid("parameters must span a whole path segment in %q", segment)
+	}
+
+	prefix, glob := strings.CutSuffix(segment, "*")
+	if strings.Contains(prefix, "*") || (glob && !last) {
+		invalid("* is only supported at the end of a pattern")
+
+		return regexp.QuoteMeta(segment)
+	}
+	if glob {
+		return regexp.QuoteMeta(prefix) + ".*"
+	}
+
+	return regexp.QuoteMeta(segment)
+}
@@ -4,16 +4,24 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
 func (auth *AuthModule) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if auth.tenantResolver != nil {
+			c.Request = c.Request.WithContext(
+				SetTenant(c.Request.Context(), auth.tenantResolver(c.Request)),
+			)
+		}
+
 		user := GetAuthenticatedUser(c.Request.Context())
+		tenant := GetTenant(c.Request.Context())
 		method := c.Request.Method
 		path := c.Request.URL.Path
 
-		allowed, err := auth.enforcer.Enforce(user, path, method)
+		allowed, err := auth.enforce(user, tenant, path, method)
 		if err != nil {
 			log.Error().Err(err).Msg("Authorization check failed")
 			c.AbortWithStatus(http.StatusInternalServerError)
@@ -23,9 +31,13 @@ func (auth *AuthModule) Middleware() gin.HandlerFunc {
 		if !allowed {
 			log.Warn().
 				Str("user", user).
+				Str("tenant", tenant).
 				Str("path", path).
 				Str("method", method).
 				Msg("Unauthorized access attempt")
+			if auth.explainDenials {
+				auth.logExplanation(user, tenant, path, method)
+			}
 			c.AbortWithStatus(http.StatusForbidden)
 
 			return
@@ -33,3 +45,63 @@ func (auth *AuthModule) Middleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AdminMiddleware only lets members of the enclave_admin group pass. It is
+// used for the auth administration endpoints in addition to Middleware.
+func (auth *AuthModule) AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := GetAuthenticatedUser(c.Request.Context())
+
+		isAdmin, err := auth.IsAdmin(user)
+		if err != nil {
+			log.Error().Err(err).Msg("Admin check failed")
+			c.AbortWithStatus(http.StatusInternalServerError)
+
+			return
+		}
+		if !isAdmin {
+			log.Warn().
+				Str("user", user).
+				Str("path", c.Request.URL.Path).
+				Msg("Non-admin access attempt to admin endpoint")
+			c.AbortWithStatus(http.StatusForbidden)
+
+			return
+		}
+		c.Next()
+	}
+}
+
+// logExplanation logs why a request was denied at debug level.
+func (auth *AuthModule) logExplanation(user, tenant, resource, action string) {
+	event := log.Debug()
+	if !event.Enabled() {
+		return
+	}
+
+	explanation, err := auth.ExplainInTenant(tenant, user, resource, action)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to explain authorization decision")
+
+		return
+	}
+
+	nearMisses := zerolog.Arr()
+	for _, nearMiss := range explanation.NearMisses {
+		nearMisses.Dict(zerolog.Dict().
+			Str("userGroup", nearMiss.Policy.UserGroup).
+			Str("resourceGroup", nearMiss.Policy.ResourceGroup).
+			Str("permission", nearMiss.Policy.Permission).
+			Str("mismatch", string(nearMiss.Mismatch)))
+	}
+
+	event.
+		Str("user", user).
+		Str("tenant", tenant).
+		Str("resource", resource).
+		Str("action", action).
+		Strs("userGroups", explanation.UserGroups).
+		Strs("resourceGroups", explanation.ResourceGroups).
+		Array("nearMisses", nearMisses).
+		Msg("Authorization denied")
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/casbin/casbin/v3/model"
+	"github.com/casbin/casbin/v3/persist"
+	"github.com/rs/zerolog/log"
+)
+
+// legacyModel is the casbin model before policies had an effect. It is only
+// used to read policies stored with it.
+const legacyModel = `
+	[request_definition]
+	r = sub, obj, act
+
+	[policy_definition]
+	p = sub, obj, act
+
+	[role_definition]
+	g = _, _
+	g2 = _, _
+
+	[policy_effect]
+	e = some(where (p.eft == allow))
+
+	[matchers]
+	m = r.sub == p.sub && r.obj == p.obj && r.act == p.act
+`
+
+// migratePolicyEffects rewrites policies stored without an effect as allow
+// policies of current. It reports false if the stored policies are not in the
+// legacy format either.
+func migratePolicyEffects(
+	adapter persist.Adapter,
+	current model.Model,
+) (bool, error) {
+	legacy, err := model.NewModelFromString(legacyModel)
+	if err != nil {
+		return false, fmt.Errorf("create legacy model: %w", err)
+	}
+	err = adapter.LoadPolicy(legacy)
+	if err != nil {
+		return false, nil //nolint:nilerr // Not in the legacy format
+	}
+
+	migrated := current.Copy()
+	migrated.ClearPolicy()
+	for sec, ptypes := range map[string][]string{
+		"p": {"p"},
+		"g": {string(UserGroupType), string(ResourceGroupType)},
+	} {
+		for _, ptype := range ptypes {
+			rules, err := legacy.GetPolicy(sec, ptype)
+			if err != nil {
+				return false, fmt.Errorf("read legacy %s rules: %w", ptype, err)
+			}
+			for _, rule := range rules {
+				if sec == "p" {
+					rule = append(slices.Clone(rule), string(EffectAllow))
+				}
+				err = migrated.AddPolicy(sec, ptype, rule)
+				if err != nil {
+					return false, fmt.Errorf("migrate %s rule: %w", ptype, err)
+				}
+			}
+		}
+	}
+
+	err = adapter.SavePolicy(migrated)
+	if err != nil {
+		return false, fmt.Errorf("save migrated policies: %w", err)
+	}
+
+	policies, _ := migrated.GetPolicy("p", "p")
+	log.Info().
+		Int("policies", len(policies)).
+		Msg("Migrated stored casbin policies to allow effect")
+
+	return true, nil
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"sync/atomic"
+
+	"github.com/casbin/casbin/v3"
+	"github.com/casbin/casbin/v3/persist"
+	"github.com/rs/zerolog/log"
+)
+
+// notImplemented is the error message casbin adapters return for auto save
+// operations they do not support.
+const notImplemented = "not implemented"
+
+// persister writes committed rule changes to the adapter. Adapters supporting
+// auto save get only the changed rules; all others, like the file adapter,
+// get a full SavePolicy.
+type persister struct {
+	adapter     persist.Adapter
+	incremental atomic.Bool
+}
+
+func newPersister(adapter persist.Adapter) *persister {
+	p := &persister{adapter: adapter}
+	p.incremental.Store(true)
+
+	return p
+}
+
+// save persists the changes applied to enforcer.
+func (p *persister) save(
+	enforcer *casbin.Enforcer,
+	changes []ruleChange,
+) error {
+	if p.incremental.Load() {
+		persisted, err := p.saveIncremental(changes)
+		switch {
+		case err == nil:
+			return nil
+		case err.Error() == notImplemented:
+			// The full save below overwrites anything persisted so far
+			p.incremental.Store(false)
+			log.Debug().
+				Msg("Adapter does not support auto save, falling back to full saves")
+		default:
+			p.undo(persisted)
+
+			return &CasbinError{"SavePolicy", err}
+		}
+	}
+
+	err := enforcer.SavePolicy()
+	if err != nil {
+		return &CasbinError{"SavePolicy", err}
+	}
+
+	return nil
+}
+
+// saveIncremental writes the changes one by one and returns those that were
+// persisted before an error occurred.
+func (p *persister) saveIncremental(
+	changes []ruleChange,
+) ([]ruleChange, error) {
+	for i, change := range changes {
+		err := p.write(change)
+		if err != nil {
+			return changes[:i], err
+		}
+	}
+
+	return changes, nil
+}
+
+// undo reverts persisted changes on a best effort basis.
+func (p *persister) undo(persisted []ruleChange) {
+	for i := len(persisted) - 1; i >= 0; i-- {
+		err := p.write(persisted[i].inverse())
+		if err != nil {
+			log.Error().
+				Err(err).
+				Strs("rule", persisted[i].rule).
+				Msg("Failed to undo persisted policy change")
+		}
+	}
+}
+
+func (p *persister) write(change ruleChange) error {
+	sec := change.ptype[:1]
+	if change.remove {
+		//nolint:wrapcheck // Wrapped by save
+		return p.adapter.RemovePolicy(sec, change.ptype, change.rule)
+	}
+
+	//nolint:wrapcheck // Wrapped by save
+	return p.adapter.AddPolicy(sec, change.ptype, change.rule)
+}
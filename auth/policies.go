@@ -1,12 +1,76 @@
 package auth
 
+import (
+	"fmt"
+)
+
+// Effect decides whether a policy allows or denies the requests it matches.
+// Deny overrides allow.
+type Effect string
+
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
 type Policy struct {
-	UserGroup     string
-	ResourceGroup string
-	Permission    string
+	UserGroup     string `json:"userGroup"        yaml:"userGroup"`
+	ResourceGroup string `json:"resourceGroup"    yaml:"resourceGroup"`
+	Permission    string `json:"permission"       yaml:"permission"`
+	// Effect is EffectAllow if empty. Listed policies always have it set.
+	Effect Effect `json:"effect,omitempty" yaml:"effect,omitempty"`
 }
 
-// AddPolicy adds a policy to the enforcer if it does not already exist.
+// adminPolicy grants enclave_admin full access. It can never be removed.
+var adminPolicy = Policy{enclaveAdminGroup, "*", "*", EffectAllow}
+
+// normalize returns the policy with the default effect filled in.
+func (p Policy) normalize() Policy {
+	if p.Effect == "" {
+		p.Effect = EffectAllow
+	}
+
+	return p
+}
+
+func (p Policy) rule() []string {
+	p = p.normalize()
+
+	return []string{p.UserGroup, p.ResourceGroup, p.Permission, string(p.Effect)}
+}
+
+func policyFromRule(rule []string) Policy {
+	return Policy{
+		UserGroup:     rule[0],
+		ResourceGroup: rule[1],
+		Permission:    rule[2],
+		Effect:        Effect(rule[3]),
+	}
+}
+
+// validatePolicyEffect rejects unknown effects and deny policies for
+// enclave_admin, which would never apply.
+func validatePolicyEffect(policy Policy) error {
+	switch policy.normalize().Effect {
+	case EffectAllow:
+		return nil
+	case EffectDeny:
+		if policy.UserGroup == enclaveAdminGroup {
+			// Admins must not be able to lock themselves out
+			return &ConflictError{"Deny policies cannot name enclave_admin"}
+		}
+
+		return nil
+	default:
+		return &ValidationError{
+			fmt.Sprintf("unknown policy effect %q", policy.Effect),
+		}
+	}
+}
+
+// AddPolicy adds a policy to the enforcer if it does not already exist. The
+// method is an HTTP method, another action, "*" for all actions or the name of
+// an action set like "read", see WithActionSets.
 //
 // It checks if the user group and resource group exist before adding the policy
 // and throws if they
@@ -14,53 +78,97 @@ type Policy struct {
 func (auth *AuthModule) AddPolicy(
 	userGroup, resourceGroup, method string,
 ) error {
-	if userGroup != "*" {
-		ugExists, err := auth.UserGroupExists(userGroup)
+	return auth.addPolicy(
+		Policy{userGroup, resourceGroup, method, EffectAllow},
+	)
+}
+
+// AddDenyPolicy adds a policy that denies method on resourceGroup for
+// userGroup, even if other policies allow it, also for members of
+// enclave_admin. Deny policies naming enclave_admin itself or overriding
+// protected policies, see Protect, are rejected. Like AddPolicy, both groups
+// have to exist.
+func (auth *AuthModule) AddDenyPolicy(
+	userGroup, resourceGroup, method string,
+) error {
+	return auth.addPolicy(Policy{userGroup, resourceGroup, method, EffectDeny})
+}
+
+func (auth *AuthModule) addPolicy(policy Policy) error {
+	return auth.mutate(func() (mutation, error) {
+		return auth.planPolicy(policy)
+	})
+}
+
+// planPolicy validates policy and plans adding it.
+func (auth *AuthModule) planPolicy(policy Policy) (mutation, error) {
+	err := validatePolicyEffect(policy)
+	if err != nil {
+		return mutation{}, err
+	}
+	_, err = policy.tenant()
+	if err != nil {
+		return mutation{}, err
+	}
+
+	if policy.UserGroup != "*" {
+		ugExists, err := auth.userGroupManager.GroupExists(policy.UserGroup)
 		if err != nil {
-			return err
+			return mutation{}, err
 		}
 		if !ugExists {
-			return &NotFoundError{"userGroup", userGroup}
+			return mutation{}, &NotFoundError{"userGroup", policy.UserGroup}
 		}
 	}
 
-	if resourceGroup != "*" {
-		rgExists, err := auth.ResourceGroupExists(resourceGroup)
+	switch {
+	case policy.ResourceGroup == "*":
+	case isTenantWildcard(policy.ResourceGroup):
+		err = checkTenant(auth.enforcer, policy.ResourceGroup)
+		if err != nil {
+			return mutation{}, err
+		}
+	default:
+		rgExists, err := auth.resourceGroupManager.GroupExists(
+			policy.ResourceGroup,
+		)
 		if err != nil {
-			return err
+			return mutation{}, err
 		}
 		if !rgExists {
-			return &NotFoundError{"resourceGroup", resourceGroup}
+			return mutation{}, &NotFoundError{
+				"resourceGroup",
+				policy.ResourceGroup,
+			}
 		}
 	}
 
-	filteredPolicies, err := auth.enforcer.GetFilteredPolicy(
-		0,
-		userGroup,
-		resourceGroup,
-		method,
-	)
-	if err != nil {
-		return &CasbinError{"GetFilteredPolicy", err}
-	}
-	if len(filteredPolicies) > 0 {
-		return nil
-	}
+	// Existing policies are skipped when applying the mutation
+	m := mutation{action: "AddPolicy"}
+	m.add("p", policy.rule()...)
+
+	return m, nil
+}
 
-	_, err = auth.enforcer.AddPolicy(userGroup, resourceGroup, method)
+// ListPolicies returns all policies sorted by user group, resource group,
+// permission and effect.
+func (auth *AuthModule) ListPolicies() ([]Policy, error) {
+	defer auth.rlock()()
+
+	policies, err := auth.listPolicies()
 	if err != nil {
-		return &CasbinError{"AddPolicy", err}
+		return nil, err
 	}
 
-	err = auth.enforcer.SavePolicy()
+	page, err := paginate(policies, policyKey, ListOptions{})
 	if err != nil {
-		return &CasbinError{"AddPolicy", err}
+		return nil, err
 	}
 
-	return nil
+	return page.Items, nil
 }
 
-func (auth *AuthModule) ListPolicies() ([]Policy, error) {
+func (auth *AuthModule) listPolicies() ([]Policy, error) {
 	rawPolicies, err := auth.enforcer.GetPolicy()
 	if err != nil {
 		return nil, &CasbinError{"GetPolicy", err}
@@ -68,12 +176,7 @@ func (auth *AuthModule) ListPolicies() ([]Policy, error) {
 
 	policies := make([]Policy, 0, len(rawPolicies))
 	for _, rawPolicy := range rawPolicies {
-		policy := Policy{
-			UserGroup:     rawPolicy[0],
-			ResourceGroup: rawPolicy[1],
-			Permission:    rawPolicy[2],
-		}
-		policies = append(policies, policy)
+		policies = append(policies, policyFromRule(rawPolicy))
 	}
 
 	return policies, nil
@@ -81,25 +184,42 @@ func (auth *AuthModule) ListPolicies() ([]Policy, error) {
 
 // RemovePolicy removes a policy from the enforcer.
 //
-// It prevents the removal of the enclaveAdmin policy to ensure that
-// enclaveAdmins always have full
-// access.
+// Protected policies, like the one ensuring that enclaveAdmins always have
+// full access, cannot be removed, see Protect.
 func (auth *AuthModule) RemovePolicy(
 	userGroup, resourceGroup, method string,
 ) error {
-	if userGroup == enclaveAdminGroup && resourceGroup == "*" && method == "*" {
-		return &ConflictError{"The provided policy cannot be removed"}
-	}
+	return auth.removePolicy(
+		Policy{userGroup, resourceGroup, method, EffectAllow},
+	)
+}
 
-	_, err := auth.enforcer.RemovePolicy(userGroup, resourceGroup, method)
-	if err != nil {
-		return &CasbinError{"RemovePolicy", err}
-	}
+// RemoveDenyPolicy removes a policy added with AddDenyPolicy.
+func (auth *AuthModule) RemoveDenyPolicy(
+	userGroup, resourceGroup, method string,
+) error {
+	return auth.removePolicy(
+		Policy{userGroup, resourceGroup, method, EffectDeny},
+	)
+}
 
-	err = auth.enforcer.SavePolicy()
-	if err != nil {
-		return &CasbinError{"RemovePolicy", err}
+// isTenantAdminPolicy reports whether policy is the policy of a tenant admin
+// group, which is only removed together with the tenant.
+func isTenantAdminPolicy(policy Policy) bool {
+	tenant, _ := SplitTenant(policy.UserGroup)
+
+	return tenant != "" && policy == tenantAdminPolicy(tenant)
+}
+
+func (auth *AuthModule) removePolicy(policy Policy) error {
+	if isTenantAdminPolicy(policy) {
+		return &ConflictError{"The provided policy cannot be removed"}
 	}
 
-	return nil
+	return auth.mutate(func() (mutation, error) {
+		m := mutation{action: "RemovePolicy"}
+		m.remove("p", policy.rule()...)
+
+		return m, nil
+	})
 }
@@ -0,0 +1,314 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+)
+
+// protection marks the groups, members and policies of a document as system
+// managed, see Protect.
+type protection struct {
+	name     string
+	document PolicyDocument
+}
+
+// protections are the protections of a module. They are shared by all copies
+// of the module and guarded by its lock. Protections added in a transaction
+// take effect once it is committed.
+type protections []protection
+
+// enclaveAdminProtection protects the enclave_admin group and its policy.
+var enclaveAdminProtection = protection{
+	name: enclaveAdminGroup,
+	document: PolicyDocument{
+		UserGroups: map[string][]string{enclaveAdminGroup: nil},
+		Policies:   []Policy{adminPolicy},
+	},
+}
+
+// Protect applies document like ApplyDocument without pruning and marks
+// everything it declares as system managed under name: its groups cannot be
+// removed, renamed, merged into others or have their metadata updated, its
+// members cannot be removed from their groups and its policies cannot be
+// removed, bound in time or overridden by deny policies, see checkOverrides.
+// Attempts return a ConflictError naming the protection, like protecting a
+// document whose policies existing deny policies override. Pruning documents
+// keeps protected entries. enclave_admin and its policy are always protected.
+func (auth *AuthModule) Protect(name string, document PolicyDocument) error {
+	if name == "" {
+		return &ValidationError{"protection name must not be empty"}
+	}
+
+	normalized := PolicyDocument{
+		UserGroups:     document.UserGroups,
+		ResourceGroups: document.ResourceGroups,
+		Policies:       make([]Policy, 0, len(document.Policies)),
+	}
+	for _, policy := range document.Policies {
+		normalized.Policies = append(normalized.Policies, policy.normalize())
+	}
+	added := protection{name: name, document: normalized}
+
+	return auth.Update(func(tx *Tx) error {
+		_, err := tx.ApplyDocument(document, ApplyOptions{})
+		if err != nil {
+			return err
+		}
+
+		// Protected rules are permanent
+		err = tx.mutate(func() (mutation, error) {
+			bounds, err := timeBounds(tx.enforcer)
+			if err != nil {
+				return mutation{}, err
+			}
+
+			m := mutation{action: "Protect", keepBounds: true}
+			for _, bound := range bounds {
+				change := ruleChange{ptype: bound.boundsType(), rule: bound.stored}
+				if added.violation(change) != "" {
+					m.remove(change.ptype, change.rule...)
+				}
+			}
+
+			return m, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		rules, err := tx.enforcer.GetPolicy()
+		if err != nil {
+			return &CasbinError{"GetPolicy", err}
+		}
+		existing := make([]ruleChange, 0, len(rules))
+		for _, rule := range rules {
+			existing = append(existing, ruleChange{ptype: "p", rule: rule})
+		}
+		err = tx.checkOverrides(protections{added}, existing)
+		if err != nil {
+			return err
+		}
+		tx.protected = append(tx.protected, added)
+
+		return nil
+	})
+}
+
+// check returns a ConflictError if any of changes removes or modifies
+// protected entries.
+func (ps protections) check(changes []ruleChange) error {
+	for _, change := range changes {
+		for _, p := range ps {
+			violation := p.violation(change)
+			if violation != "" {
+				return &ConflictError{
+					fmt.Sprintf("%s is protected by %s", violation, p.name),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// violation describes the entry change removes or modifies if it is
+// protected, otherwise it returns "".
+func (p protection) violation(change ruleChange) string {
+	rule := change.rule
+	switch change.ptype {
+	case groupRegistryType:
+		if change.remove && p.group(GroupType(rule[0]), rule[1]) {
+			return fmt.Sprintf("Group %s", rule[1])
+		}
+	case string(UserGroupType), string(ResourceGroupType):
+		if change.remove && p.member(GroupType(change.ptype), rule[0], rule[1]) {
+			return fmt.Sprintf("Member %s of group %s", rule[0], rule[1])
+		}
+	case membershipBoundsType:
+		// Bounding a protected membership in time modifies it
+		if p.member(UserGroupType, rule[0], rule[1]) {
+			return fmt.Sprintf("Member %s of group %s", rule[0], rule[1])
+		}
+	case "p":
+		if change.remove &&
+			slices.Contains(p.document.Policies, policyFromRule(rule)) {
+			return fmt.Sprintf("Policy %v", rule)
+		}
+	case policyBoundsType:
+		if slices.Contains(p.document.Policies, policyFromRule(rule[:4])) {
+			return fmt.Sprintf("Policy %v", rule[:4])
+		}
+	}
+
+	return ""
+}
+
+// checkOverrides returns a ConflictError if any of changes adds a deny policy
+// that overrides a policy protected by ps for some request: their user groups,
+// resource groups, permissions and tenants overlap. The policy of
+// enclave_admin may be overridden, see AddDenyPolicy.
+func (auth *AuthModule) checkOverrides(
+	ps protections,
+	changes []ruleChange,
+) error {
+	for _, change := range changes {
+		if change.remove || change.ptype != "p" {
+			continue
+		}
+		deny := policyFromRule(change.rule)
+		if deny.Effect != EffectDeny {
+			continue
+		}
+
+		for _, p := range ps {
+			for _, protected := range p.document.Policies {
+				if protected.Effect != EffectAllow || protected == adminPolicy {
+					continue
+				}
+
+				overrides, err := auth.overrides(deny, protected)
+				if err != nil {
+					return err
+				}
+				if overrides {
+					return &ConflictError{fmt.Sprintf(
+						"Policy %v overrides policy %v protected by %s",
+						change.rule,
+						protected.rule(),
+						p.name,
+					)}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// overrides reports whether the deny policy covers any request the allow
+// policy covers. User groups overlap if they are equal, "*" or nested, resource
+// groups if they are equal, "*" or have matching resources.
+func (auth *AuthModule) overrides(deny, allow Policy) (bool, error) {
+	denyTenant, _ := deny.tenant()
+	allowTenant, _ := allow.tenant()
+	if denyTenant != "" && allowTenant != "" && denyTenant != allowTenant {
+		return false, nil
+	}
+	if !auth.actionsOverlap(deny.Permission, allow.Permission) {
+		return false, nil
+	}
+
+	subjects, err := auth.userGroupsOverlap(deny.UserGroup, allow.UserGroup)
+	if err != nil || !subjects {
+		return false, err
+	}
+
+	return auth.resourceGroupsOverlap(deny.ResourceGroup, allow.ResourceGroup)
+}
+
+// actionsOverlap reports whether some action is covered by both permissions.
+func (auth *AuthModule) actionsOverlap(a, b string) bool {
+	if a == "*" || b == "*" {
+		return true
+	}
+	actions := append([]string{a}, auth.actionSets[a]...)
+
+	return slices.ContainsFunc(actions, func(action string) bool {
+		return auth.actionCovers(b, action)
+	}) || slices.ContainsFunc(auth.actionSets[b], func(action string) bool {
+		return auth.actionCovers(a, action)
+	})
+}
+
+func (auth *AuthModule) userGroupsOverlap(a, b string) (bool, error) {
+	if a == b || a == "*" || b == "*" {
+		return true, nil
+	}
+
+	roles := auth.enforcer.GetNamedRoleManager(string(UserGroupType))
+	for _, link := range [][2]string{{a, b}, {b, a}} {
+		nested, err := roles.HasLink(link[0], link[1])
+		if err != nil {
+			return false, &CasbinError{"HasLink", err}
+		}
+		if nested {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (auth *AuthModule) resourceGroupsOverlap(a, b string) (bool, error) {
+	if a == b || a == "*" || b == "*" {
+		return true, nil
+	}
+
+	members := make([][]string, 0, 2)
+	for _, group := range []string{a, b} {
+		rules, err := auth.enforcer.GetFilteredNamedGroupingPolicy(
+			string(ResourceGroupType),
+			1,
+			group,
+		)
+		if err != nil {
+			return false, &CasbinError{"GetFilteredNamedGroupingPolicy", err}
+		}
+		resources := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			resources = append(resources, rule[0])
+		}
+		members = append(members, resources)
+	}
+
+	for _, x := range members[0] {
+		for _, y := range members[1] {
+			if x == y || keyMatch(x, y) || keyMatch(y, x) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// group reports whether the group name of groupType is protected.
+func (p protection) group(groupType GroupType, name string) bool {
+	_, ok := p.groups(groupType)[name]
+
+	return ok
+}
+
+// member reports whether member of group is protected.
+func (p protection) member(groupType GroupType, member, group string) bool {
+	return slices.Contains(p.groups(groupType)[group], member)
+}
+
+func (p protection) groups(groupType GroupType) map[string][]string {
+	if groupType == ResourceGroupType {
+		return p.document.ResourceGroups
+	}
+
+	return p.document.UserGroups
+}
+
+// group reports whether any protection protects the group name of groupType.
+func (ps protections) group(groupType GroupType, name string) bool {
+	return slices.ContainsFunc(ps, func(p protection) bool {
+		return p.group(groupType, name)
+	})
+}
+
+// member reports whether any protection protects member of group.
+func (ps protections) member(groupType GroupType, member, group string) bool {
+	return slices.ContainsFunc(ps, func(p protection) bool {
+		return p.member(groupType, member, group)
+	})
+}
+
+// policy reports whether any protection protects policy.
+func (ps protections) policy(policy Policy) bool {
+	return slices.ContainsFunc(ps, func(p protection) bool {
+		return slices.Contains(p.document.Policies, policy)
+	})
+}
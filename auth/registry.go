@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v3"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// groupRegistryType registers every user and resource group with its
+	// GroupInfo as group type, name, description, owner, created, creator. A
+	// group exists if and only if it is registered.
+	groupRegistryType = "p4"
+	// unset marks an empty field of a stored GroupInfo, some adapters drop
+	// empty fields.
+	unset = "_"
+)
+
+// GroupInfo is the metadata of a user or resource group.
+type GroupInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Owner is the user responsible for the group. Owning a group grants no
+	// permissions.
+	Owner string `json:"owner,omitempty"`
+	// CreatedAt is zero for groups created before groups had metadata.
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+	// CreatedBy is the user who created the group, empty if unknown.
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// rule returns the registry rule of the group of groupType.
+func (info GroupInfo) rule(groupType GroupType) []string {
+	created := unset
+	if !info.CreatedAt.IsZero() {
+		created = info.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return []string{
+		string(groupType),
+		info.Name,
+		encodeField(info.Description),
+		encodeField(info.Owner),
+		created,
+		encodeField(info.CreatedBy),
+	}
+}
+
+func groupInfoFromRule(rule []string) (GroupInfo, error) {
+	//nolint:mnd // Fields of a registry rule
+	if len(rule) != 6 {
+		return GroupInfo{}, &ValidationError{
+			fmt.Sprintf("%s rule %v must have six fields", groupRegistryType, rule),
+		}
+	}
+
+	info := GroupInfo{Name: rule[1]}
+	for field, stored := range map[*string]string{
+		&info.Description: rule[2],
+		&info.Owner:       rule[3],
+		&info.CreatedBy:   rule[5],
+	} {
+		decoded, err := decodeField(stored)
+		if err != nil {
+			return GroupInfo{}, &ValidationError{
+				fmt.Sprintf("invalid field %q of group %s", stored, info.Name),
+			}
+		}
+		*field = decoded
+	}
+
+	if rule[4] != unset {
+		created, err := time.Parse(time.RFC3339Nano, rule[4])
+		if err != nil {
+			return GroupInfo{}, &ValidationError{
+				fmt.Sprintf("invalid creation time %q of group %s", rule[4], info.Name),
+			}
+		}
+		info.CreatedAt = created
+	}
+
+	return info, nil
+}
+
+// encodeField escapes free text for the CSV of the file adapter, which does
+// not quote fields. "_" is escaped as well, so only empty fields are unset.
+func encodeField(value string) string {
+	if value == "" {
+		return unset
+	}
+
+	return strings.ReplaceAll(url.QueryEscape(value), "_", "%5F")
+}
+
+func decodeField(stored string) (string, error) {
+	if stored == unset {
+		return "", nil
+	}
+
+	//nolint:wrapcheck // Wrapped by groupInfoFromRule
+	return url.QueryUnescape(stored)
+}
+
+// registration returns the registry rule of groupName, nil if the group does
+// not exist.
+func (gm *groupManager[T]) registration(groupName string) ([]string, error) {
+	rules, err := gm.enforcer.GetFilteredNamedPolicy(
+		groupRegistryType,
+		0,
+		string(gm.groupType),
+		groupName,
+	)
+	if err != nil {
+		return nil, &CasbinError{"GetFilteredNamedPolicy", err}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	return rules[0], nil
+}
+
+// registrations returns the registry rules of all groups by group name.
+func (gm *groupManager[T]) registrations() (map[string][]string, error) {
+	rules, err := gm.enforcer.GetFilteredNamedPolicy(
+		groupRegistryType,
+		0,
+		string(gm.groupType),
+	)
+	if err != nil {
+		return nil, &CasbinError{"GetFilteredNamedPolicy", err}
+	}
+
+	registered := make(map[string][]string, len(rules))
+	for _, rule := range rules {
+		registered[rule[1]] = rule
+	}
+
+	return registered, nil
+}
+
+// GroupNames returns the sorted names of all groups, including groups without
+// members.
+func (gm *groupManager[T]) GroupNames() ([]string, error) {
+	registered, err := gm.registrations()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(registered))
+	for name := range registered {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// GetInfo returns the metadata of groupName.
+func (gm *groupManager[T]) GetInfo(groupName string) (GroupInfo, error) {
+	rule, err := gm.registration(groupName)
+	if err != nil {
+		return GroupInfo{}, err
+	}
+	if rule == nil {
+		return GroupInfo{}, &NotFoundError{gm.groupName, groupName}
+	}
+
+	return groupInfoFromRule(rule)
+}
+
+// UpdateInfo replaces the description and owner of the group info.Name. The
+// creation time and creator are kept.
+func (gm *groupManager[T]) UpdateInfo(info GroupInfo) (mutation, error) {
+	rule, err := gm.registration(info.Name)
+	if err != nil {
+		return mutation{}, err
+	}
+	if rule == nil {
+		return mutation{}, &NotFoundError{gm.groupName, info.Name}
+	}
+	current, err := groupInfoFromRule(rule)
+	if err != nil {
+		return mutation{}, err
+	}
+	current.Description = info.Description
+	current.Owner = info.Owner
+
+	// Replacing the registration does not remove the group or its time bounds
+	m := mutation{action: "UpdateGroupInfo", keepBounds: true}
+	m.remove(groupRegistryType, rule...)
+	m.add(groupRegistryType, current.rule(gm.groupType)...)
+
+	return m, nil
+}
+
+// migrateGroupRegistry registers the groups of loaded rules stored before the
+// registry existed, when placeholder members marked empty groups, and removes
+// those placeholders. Their creation time and creator stay unknown.
+func migrateGroupRegistry(enforcer *casbin.Enforcer) error {
+	for groupType, nullName := range map[GroupType]string{
+		UserGroupType:     nullUser,
+		ResourceGroupType: nullResource,
+	} {
+		rules, err := enforcer.GetNamedGroupingPolicy(string(groupType))
+		if err != nil {
+			return &CasbinError{"GetNamedGroupingPolicy", err}
+		}
+		// Removing placeholders below changes the rules in place
+		rules = slices.Clone(rules)
+
+		migrated := 0
+		for _, rule := range rules {
+			group := rule[1]
+			registered, err := enforcer.GetFilteredNamedPolicy(
+				groupRegistryType,
+				0,
+				string(groupType),
+				group,
+			)
+			if err != nil {
+				return &CasbinError{"GetFilteredNamedPolicy", err}
+			}
+			if len(registered) == 0 {
+				_, err = enforcer.AddNamedPolicy(
+					groupRegistryType,
+					GroupInfo{Name: group}.rule(groupType),
+				)
+				if err != nil {
+					return &CasbinError{"AddNamedPolicy", err}
+				}
+				migrated++
+			}
+
+			if rule[0] == nullName {
+				_, err = enforcer.RemoveNamedGroupingPolicy(string(groupType), rule)
+				if err != nil {
+					return &CasbinError{"RemoveNamedGroupingPolicy", err}
+				}
+			}
+		}
+
+		if migrated > 0 {
+			log.Info().
+				Str("groupType", string(groupType)).
+				Int("groups", migrated).
+				Msg("Migrated groups to the group registry")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,352 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/casbin/casbin/v3/model"
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// reloadDelay is how long the reloader waits for further writes before it
+// reloads, so editors writing a file in several steps trigger one reload.
+const reloadDelay = 100 * time.Millisecond
+
+// WithPolicyFileReload reloads the policies from the adapter whenever the file
+// at path, usually the CSV of the file adapter, is changed by someone else.
+// A changed file is validated like a PolicyDocument and must keep the
+// enclave_admin group and policy; if it is invalid, the previous state stays
+// active. Reloads that change policies notify the watcher set with
+// WithWatcher. The file is watched until Close is called.
+func WithPolicyFileReload(path string) Option {
+	return func(auth *AuthModule) {
+		auth.reloadPath = path
+	}
+}
+
+// policyFile watches the policy file of WithPolicyFileReload.
+type policyFile struct {
+	path    string
+	watcher *fsnotify.Watcher
+	// saved is the hash of the file after the last save of the module, so the
+	// events caused by it can be told apart from changes of someone else.
+	saved atomic.Pointer[[sha256.Size]byte]
+}
+
+// hash returns the hash of the current content of the file.
+func (f *policyFile) hash() ([sha256.Size]byte, error) {
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("read policy file: %w", err)
+	}
+
+	return sha256.Sum256(content), nil
+}
+
+// recordSave remembers the content the module saved to the file.
+func (f *policyFile) recordSave() {
+	hash, err := f.hash()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to hash saved policy file")
+		f.saved.Store(nil)
+
+		return
+	}
+	f.saved.Store(&hash)
+}
+
+// savedByModule reports whether the file still has the content the module
+// saved last.
+func (f *policyFile) savedByModule() bool {
+	saved := f.saved.Load()
+	if saved == nil {
+		return false
+	}
+	hash, err := f.hash()
+
+	return err == nil && hash == *saved
+}
+
+// Close stops watching the policy file and the sweeper started by
+// WithTimeBoundSweeper. It does not close watchers passed to WithWatcher, they
+// are owned by the caller. Closing a module again does nothing.
+func (auth *AuthModule) Close() error {
+	lc := auth.lifecycle
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if lc.closed {
+		return nil
+	}
+	lc.closed = true
+
+	if lc.stopSweeper != nil {
+		lc.stopSweeper()
+	}
+	if lc.reloader == nil {
+		return nil
+	}
+
+	err := lc.reloader.watcher.Close()
+	if err != nil {
+		return fmt.Errorf("close policy file watcher: %w", err)
+	}
+
+	return nil
+}
+
+// watchPolicyFile starts reloading the policies on changes of path.
+func (auth *AuthModule) watchPolicyFile(path string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create policy file watcher: %w", err)
+	}
+
+	// Watch the directory, editors often replace the file instead of writing it
+	err = watcher.Add(filepath.Dir(path))
+	if err != nil {
+		_ = watcher.Close()
+
+		return fmt.Errorf("watch %s: %w", filepath.Dir(path), err)
+	}
+	path = filepath.Clean(path)
+	reloader := &policyFile{path: path, watcher: watcher}
+	reloader.recordSave()
+	auth.lifecycle.reloader = reloader
+
+	go func() {
+		var timer *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					if timer != nil {
+						timer.Stop()
+					}
+
+					return
+				}
+				if filepath.Clean(event.Name) != path ||
+					!event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(reloadDelay, auth.reloadPolicyFile)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error().Err(err).Msg("Policy file watcher failed")
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (auth *AuthModule) reloadPolicyFile() {
+	if auth.lifecycle.reloader.savedByModule() {
+		log.Debug().Msg("Policy file changed by own save")
+
+		return
+	}
+
+	diff, changed, err := auth.reloadPolicy()
+	if err != nil {
+		log.Error().
+			Err(err).
+			Msg("Rejected changed policy file, keeping previous policies")
+
+		return
+	}
+	if !changed {
+		log.Debug().Msg("Policy file changed without policy changes")
+
+		return
+	}
+	auth.notifyWatcher()
+
+	log.Info().
+		Strs("addedUserGroups", diff.Added.UserGroups).
+		Strs("removedUserGroups", diff.Removed.UserGroups).
+		Int("addedUserMemberships", len(diff.Added.UserMemberships)).
+		Int("removedUserMemberships", len(diff.Removed.UserMemberships)).
+		Strs("addedResourceGroups", diff.Added.ResourceGroups).
+		Strs("removedResourceGroups", diff.Removed.ResourceGroups).
+		Int("addedResourceMemberships", len(diff.Added.ResourceMemberships)).
+		Int("removedResourceMemberships", len(diff.Removed.ResourceMemberships)).
+		Int("addedPolicies", len(diff.Added.Policies)).
+		Int("removedPolicies", len(diff.Removed.Policies)).
+		Msg("Reloaded changed policy file")
+}
+
+// ReloadPolicy replaces the in-memory policies with those of the adapter and
+// returns the applied diff. The loaded policies must form a valid
+// PolicyDocument that keeps the enclave_admin group and policy, otherwise
+// nothing is changed. Time bounds are replaced as well but not part of the
+// diff. Nothing is written back to the adapter.
+func (auth *AuthModule) ReloadPolicy() (PolicyDiff, error) {
+	diff, _, err := auth.reloadPolicy()
+
+	return diff, err
+}
+
+// reloadPolicy is ReloadPolicy and also reports whether any rules, including
+// time bounds, changed.
+func (auth *AuthModule) reloadPolicy() (PolicyDiff, bool, error) {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	loaded := auth.enforcer.GetModel().Copy()
+	loaded.ClearPolicy()
+	err := auth.persister.adapter.LoadPolicy(loaded)
+	if err != nil {
+		return PolicyDiff{}, false, &ValidationError{
+			fmt.Sprintf("policy file cannot be loaded: %v", err),
+		}
+	}
+
+	document, err := documentFromModel(loaded)
+	if err != nil {
+		return PolicyDiff{}, false, err
+	}
+
+	if _, ok := document.UserGroups[enclaveAdminGroup]; !ok {
+		return PolicyDiff{}, false, &ConflictError{
+			"Policy file must keep the enclave_admin group",
+		}
+	}
+	if !slices.Contains(document.Policies, adminPolicy) {
+		return PolicyDiff{}, false, &ConflictError{
+			"Policy file must keep the enclave_admin policy",
+		}
+	}
+
+	diff, err := auth.diffDocument(document, true)
+	if err != nil {
+		return PolicyDiff{}, false, err
+	}
+	boundsChanges, err := auth.boundsChanges(loaded)
+	if err != nil {
+		return PolicyDiff{}, false, err
+	}
+
+	changes := slices.Concat(diff.changes, boundsChanges)
+	applied := make([]ruleChange, 0, len(changes))
+	for _, change := range changes {
+		ok, err := auth.applyChange(change)
+		if err != nil {
+			auth.revertChanges(applied)
+
+			return PolicyDiff{}, false, &CasbinError{"ReloadPolicy", err}
+		}
+		if ok {
+			applied = append(applied, change)
+		}
+	}
+
+	return diff, len(applied) > 0, nil
+}
+
+// documentFromModel converts the rules of a loaded model into a
+// PolicyDocument. Groups without members are kept as empty groups, marked by
+// their registration or, in rules stored before the group registry existed,
+// by placeholder members.
+func documentFromModel(m model.Model) (PolicyDocument, error) {
+	document := PolicyDocument{
+		registry: map[GroupType]map[string][]string{
+			UserGroupType:     {},
+			ResourceGroupType: {},
+		},
+	}
+
+	registrations, err := m.GetPolicy("p", groupRegistryType)
+	if err != nil {
+		return PolicyDocument{}, &CasbinError{"GetPolicy", err}
+	}
+	for _, rule := range registrations {
+		_, err = groupInfoFromRule(rule)
+		if err != nil {
+			return PolicyDocument{}, err
+		}
+		registry, ok := document.registry[GroupType(rule[0])]
+		if !ok {
+			return PolicyDocument{}, &ValidationError{
+				fmt.Sprintf(
+					"unknown group type of %s rule %v",
+					groupRegistryType,
+					rule,
+				),
+			}
+		}
+		registry[rule[1]] = rule
+	}
+
+	policies, err := m.GetPolicy("p", "p")
+	if err != nil {
+		return PolicyDocument{}, &CasbinError{"GetPolicy", err}
+	}
+	for _, rule := range policies {
+		document.Policies = append(document.Policies, policyFromRule(rule))
+	}
+
+	document.UserGroups, err = groupsFromModel(
+		m,
+		UserGroupType,
+		nullUser,
+		document.registry[UserGroupType],
+	)
+	if err != nil {
+		return PolicyDocument{}, err
+	}
+	document.ResourceGroups, err = groupsFromModel(
+		m,
+		ResourceGroupType,
+		nullResource,
+		document.registry[ResourceGroupType],
+	)
+	if err != nil {
+		return PolicyDocument{}, err
+	}
+
+	return document, nil
+}
+
+func groupsFromModel(
+	m model.Model,
+	groupType GroupType,
+	nullName string,
+	registered map[string][]string,
+) (map[string][]string, error) {
+	rules, err := m.GetPolicy("g", string(groupType))
+	if err != nil {
+		return nil, &CasbinError{"GetPolicy", err}
+	}
+
+	groups := map[string][]string{}
+	for group := range registered {
+		groups[group] = nil
+	}
+	for _, rule := range rules {
+		if len(rule) != 2 {
+			return nil, &ValidationError{
+				fmt.Sprintf("%s rule %v must have two fields", groupType, rule),
+			}
+		}
+		members := groups[rule[1]]
+		if rule[0] != nullName {
+			members = append(members, rule[0])
+		}
+		groups[rule[1]] = members
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,374 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RenameUserGroup renames the user group groupName to newName. Its members,
+// its memberships in other groups, the policies referencing it, their time
+// bounds and its metadata move to newName in one persisted step. Protected
+// groups, like enclave_admin, and the admin groups of tenants cannot be
+// renamed, see Protect. newName must not be a wildcard or resource pattern.
+func (auth *AuthModule) RenameUserGroup(groupName, newName string) error {
+	if isTenantAdminGroup(groupName) {
+		return &ConflictError{"Tenant admin group cannot be renamed"}
+	}
+
+	return auth.mutate(func() (mutation, error) {
+		return auth.userGroupManager.RenameGroup(groupName, newName)
+	})
+}
+
+// RenameResourceGroup renames the resource group groupName to newName. Its
+// resources, the policies referencing it, their time bounds and its metadata
+// move to newName in one persisted step. Protected groups cannot be renamed.
+func (auth *AuthModule) RenameResourceGroup(groupName, newName string) error {
+	return auth.mutate(func() (mutation, error) {
+		return auth.resourceGroupManager.RenameGroup(groupName, newName)
+	})
+}
+
+// MergeGroups merges the groups groupName of groupType into the existing
+// group into and removes them in one persisted step. Their members,
+// memberships in other groups and the policies referencing them move to into,
+// rules into already has are kept as they are. The metadata of into is kept.
+// Protected groups, like enclave_admin, and the admin groups of tenants can
+// neither be merged into other groups nor have groups merged into them.
+func (auth *AuthModule) MergeGroups(
+	groupType GroupType,
+	into string,
+	groupName ...string,
+) error {
+	return auth.mutate(func() (mutation, error) {
+		for _, p := range *auth.protections {
+			if p.group(groupType, into) {
+				return mutation{}, &ConflictError{fmt.Sprintf(
+					"Group %s is protected by %s",
+					into,
+					p.name,
+				)}
+			}
+		}
+
+		switch groupType {
+		case UserGroupType:
+			if isTenantAdminGroup(into) ||
+				slices.ContainsFunc(groupName, isTenantAdminGroup) {
+				return mutation{}, &ConflictError{
+					"Tenant admin group cannot be merged",
+				}
+			}
+
+			return auth.userGroupManager.MergeGroups(into, groupName...)
+		case ResourceGroupType:
+			return auth.resourceGroupManager.MergeGroups(into, groupName...)
+		default:
+			return mutation{}, &ValidationError{
+				fmt.Sprintf("unknown group type %q", groupType),
+			}
+		}
+	})
+}
+
+// RenameGroup moves everything referencing groupName to newName, see
+// RenameUserGroup.
+func (gm *groupManager[T]) RenameGroup(
+	groupName, newName string,
+) (mutation, error) {
+	err := gm.checkName(newName)
+	if err != nil {
+		return mutation{}, err
+	}
+
+	info, err := gm.GetInfo(groupName)
+	if err != nil {
+		return mutation{}, err
+	}
+	exists, err := gm.GroupExists(newName)
+	if err != nil {
+		return mutation{}, err
+	}
+	if exists {
+		return mutation{}, &ConflictError{
+			fmt.Sprintf("Group %s already exists", newName),
+		}
+	}
+
+	m, err := gm.moveGroups("RenameGroup", []string{groupName}, newName)
+	if err != nil {
+		return mutation{}, err
+	}
+	info.Name = newName
+	m.add(groupRegistryType, info.rule(gm.groupType)...)
+
+	return m, nil
+}
+
+// MergeGroups moves everything referencing the groups groupName to into, see
+// AuthModule.MergeGroups.
+func (gm *groupManager[T]) MergeGroups(
+	into string,
+	groupName ...string,
+) (mutation, error) {
+	if len(groupName) == 0 {
+		return mutation{}, &ValidationError{"no groups to merge"}
+	}
+	if slices.Contains(groupName, into) {
+		return mutation{}, &ValidationError{
+			fmt.Sprintf("group %s cannot be merged into itself", into),
+		}
+	}
+
+	for _, group := range append([]string{into}, groupName...) {
+		exists, err := gm.GroupExists(group)
+		if err != nil {
+			return mutation{}, err
+		}
+		if !exists {
+			return mutation{}, &NotFoundError{gm.groupName, group}
+		}
+	}
+
+	merged := slices.Compact(slices.Sorted(slices.Values(groupName)))
+	if gm.nested {
+		err := gm.checkMergeCycle(into, merged)
+		if err != nil {
+			return mutation{}, err
+		}
+	}
+
+	return gm.moveGroups("MergeGroups", merged, into)
+}
+
+// moveGroups plans moving the members, memberships and policies of the groups
+// moved to target and removing their registrations. Rules that end up equal to
+// a rule target already has, or make target a member of itself, are dropped.
+// Time bounds move with their rules, so the mutation keeps bounds.
+func (gm *groupManager[T]) moveGroups(
+	action string,
+	moved []string,
+	target string,
+) (mutation, error) {
+	rename := func(name string) string {
+		if slices.Contains(moved, name) {
+			return target
+		}
+
+		return name
+	}
+
+	bounds, err := timeBounds(gm.enforcer)
+	if err != nil {
+		return mutation{}, err
+	}
+	boundOf := make(map[string]timeBound, len(bounds))
+	for _, bound := range bounds {
+		boundOf[ruleKey(bound.ptype, bound.rule)] = bound
+	}
+
+	policyField := gm.policyField()
+
+	m := mutation{action: action, keepBounds: true}
+	added := map[string]bool{}
+	move := func(ptype string, rule []string) error {
+		// Only the fields naming groups of this manager are renamed, users and
+		// resources or groups of the other type may share their names
+		renamed := slices.Clone(rule)
+		if ptype == "p" {
+			renamed[policyField] = rename(rule[policyField])
+		} else {
+			renamed[1] = rename(rule[1])
+			if gm.nested {
+				renamed[0] = rename(rule[0])
+			}
+		}
+
+		if ptype == "p" {
+			policy := policyFromRule(rule)
+			if isTenantAdminPolicy(policy) {
+				return &ConflictError{fmt.Sprintf(
+					"Policy %v of a tenant admin group cannot be changed",
+					rule,
+				)}
+			}
+			_, err := policyFromRule(renamed).tenant()
+			if err != nil {
+				return err
+			}
+			err = validatePolicyEffect(policyFromRule(renamed))
+			if err != nil {
+				return err
+			}
+		}
+
+		m.remove(ptype, rule...)
+		bound, bounded := boundOf[ruleKey(ptype, rule)]
+		if bounded {
+			m.remove(bound.boundsType(), bound.stored...)
+		}
+
+		if ptype != "p" && renamed[0] == renamed[1] {
+			return nil
+		}
+		exists, err := gm.hasRule(ptype, renamed)
+		if err != nil {
+			return err
+		}
+		if exists || added[ruleKey(ptype, renamed)] {
+			return nil
+		}
+		added[ruleKey(ptype, renamed)] = true
+
+		m.add(ptype, renamed...)
+		if bounded {
+			m.add(
+				bound.boundsType(),
+				append(slices.Clone(renamed), bound.validity.fields()...)...,
+			)
+		}
+
+		return nil
+	}
+
+	for _, group := range moved {
+		registration, err := gm.registration(group)
+		if err != nil {
+			return mutation{}, err
+		}
+
+		rules, err := gm.groupRules(group)
+		if err != nil {
+			return mutation{}, err
+		}
+		for _, rule := range rules {
+			err = move(string(gm.groupType), rule)
+			if err != nil {
+				return mutation{}, err
+			}
+		}
+
+		policies, err := gm.enforcer.GetFilteredPolicy(policyField, group)
+		if err != nil {
+			return mutation{}, &CasbinError{"GetFilteredPolicy", err}
+		}
+		for _, policy := range slices.Clone(policies) {
+			err = move("p", policy)
+			if err != nil {
+				return mutation{}, err
+			}
+		}
+
+		m.remove(groupRegistryType, registration...)
+	}
+
+	return m, nil
+}
+
+// groupRules returns the rules with groupName as group and, for nested groups,
+// as member.
+func (gm *groupManager[T]) groupRules(groupName string) ([][]string, error) {
+	rules, err := gm.enforcer.GetFilteredNamedGroupingPolicy(
+		string(gm.groupType),
+		1,
+		groupName,
+	)
+	if err != nil {
+		return nil, &CasbinError{"GetFilteredNamedGroupingPolicy", err}
+	}
+	rules = slices.Clone(rules)
+
+	if gm.nested {
+		memberships, err := gm.enforcer.GetFilteredNamedGroupingPolicy(
+			string(gm.groupType),
+			0,
+			groupName,
+		)
+		if err != nil {
+			return nil, &CasbinError{"GetFilteredNamedGroupingPolicy", err}
+		}
+		for _, membership := range memberships {
+			// A group that is a member of itself is listed once
+			if membership[1] != groupName {
+				rules = append(rules, membership)
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+func (gm *groupManager[T]) hasRule(ptype string, rule []string) (bool, error) {
+	var (
+		ok  bool
+		err error
+	)
+	if ptype == "p" {
+		ok, err = gm.enforcer.HasPolicy(rule)
+	} else {
+		ok, err = gm.enforcer.HasNamedGroupingPolicy(ptype, rule)
+	}
+	if err != nil {
+		return false, &CasbinError{"HasPolicy", err}
+	}
+
+	return ok, nil
+}
+
+// checkMergeCycle rejects merging the groups merged into into if the nested
+// groups would then form a cycle through into.
+func (gm *groupManager[T]) checkMergeCycle(into string, merged []string) error {
+	rules, err := gm.enforcer.GetNamedGroupingPolicy(string(gm.groupType))
+	if err != nil {
+		return &CasbinError{"GetNamedGroupingPolicy", err}
+	}
+
+	rename := func(name string) string {
+		if slices.Contains(merged, name) {
+			return into
+		}
+
+		return name
+	}
+	parents := map[string][]string{}
+	for _, rule := range rules {
+		member, group := rename(rule[0]), rename(rule[1])
+		if member != group {
+			parents[member] = append(parents[member], group)
+		}
+	}
+
+	reached, err := gm.walk(parents[into], func(name string) ([]string, error) {
+		return parents[name], nil
+	})
+	if err != nil {
+		return err
+	}
+	if slices.Contains(reached, into) {
+		return &ConflictError{fmt.Sprintf(
+			"Merging %s into %s would create a cycle",
+			strings.Join(merged, ", "),
+			into,
+		)}
+	}
+
+	return nil
+}
+
+// isPatternName reports whether name would be read as a wildcard or a resource
+// pattern with parameters or placeholders where groups are matched.
+func isPatternName(name string) bool {
+	if strings.ContainsAny(name, "*{}") {
+		return true
+	}
+
+	return slices.ContainsFunc(strings.Split(name, "/"), func(s string) bool {
+		return strings.HasPrefix(s, ":") || s == UserPlaceholder
+	})
+}
+
+// ruleKey identifies the rule of ptype in maps.
+func ruleKey(ptype string, rule []string) string {
+	return ptype + "\x00" + strings.Join(rule, "\x00")
+}
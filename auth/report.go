@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// EffectivePermission is a resource pattern and method a user can reach,
+// together with the policy that grants it. Resource and Method are "*" for
+// wildcard grants.
+type EffectivePermission struct {
+	Resource string `json:"resource"`
+	Method   string `json:"method"`
+	Policy   Policy `json:"policy"`
+}
+
+// PermissionReport lists everything a user can do.
+type PermissionReport struct {
+	User        string                `json:"user"`
+	Permissions []EffectivePermission `json:"permissions"`
+}
+
+// EffectivePermissions returns all resource patterns and methods user can
+// reach, either directly, through one of their groups or through a policy for
+// all users. Each resource group of a policy is expanded into its resources,
+// with UserPlaceholder segments replaced by user.
+// Entries of deny policies are listed as well; they override the allowed
+// entries they overlap with. Policies of all tenants are included, their
+// groups name the tenant they apply to.
+func (auth *AuthModule) EffectivePermissions(
+	user string,
+) (PermissionReport, error) {
+	defer auth.rlock()()
+
+	policies, err := auth.subjectPolicies(user)
+	if err != nil {
+		return PermissionReport{}, err
+	}
+
+	permissions := []EffectivePermission{}
+	for _, policy := range policies {
+		if policy.ResourceGroup == "*" {
+			permissions = append(permissions, EffectivePermission{
+				Resource: "*",
+				Method:   policy.Permission,
+				Policy:   policy,
+			})
+
+			continue
+		}
+
+		groups := []string{policy.ResourceGroup}
+		if isTenantWildcard(policy.ResourceGroup) {
+			names, err := auth.resourceGroupManager.GroupNames()
+			if err != nil {
+				return PermissionReport{}, err
+			}
+			groups = slices.DeleteFunc(names, func(group string) bool {
+				return !coversResourceGroup(policy.ResourceGroup, group)
+			})
+		}
+
+		for _, group := range groups {
+			resources, err := auth.resourceGroupManager.GetEntitiesInGroup(group)
+			if err != nil {
+				return PermissionReport{}, err
+			}
+
+			for _, resource := range resources {
+				if strings.Contains(resource, UserPlaceholder) {
+					var ok bool
+					resource, ok = resolveOwner(resource, user)
+					if !ok {
+						continue
+					}
+				}
+
+				permissions = append(permissions, EffectivePermission{
+					Resource: resource,
+					Method:   policy.Permission,
+					Policy:   policy,
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(permissions, func(a, b EffectivePermission) int {
+		return cmp.Or(
+			cmp.Compare(a.Resource, b.Resource),
+			cmp.Compare(a.Method, b.Method),
+			cmp.Compare(a.Policy.UserGroup, b.Policy.UserGroup),
+			cmp.Compare(a.Policy.ResourceGroup, b.Policy.ResourceGroup),
+			cmp.Compare(a.Policy.Effect, b.Policy.Effect),
+		)
+	})
+
+	return PermissionReport{User: user, Permissions: permissions}, nil
+}
+
+// WriteJSON writes the report as a JSON document to w.
+func (r PermissionReport) WriteJSON(w io.Writer) error {
+	err := json.NewEncoder(w).Encode(r)
+	if err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV writes the report to w as CSV with a header row and one row per
+// effective permission.
+func (r PermissionReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	records := make([][]string, 0, len(r.Permissions)+1)
+	records = append(records, []string{
+		"user",
+		"resource",
+		"method",
+		"userGroup",
+		"resourceGroup",
+		"permission",
+		"effect",
+	})
+	for _, permission := range r.Permissions {
+		records = append(records, []string{
+			r.User,
+			permission.Resource,
+			permission.Method,
+			permission.Policy.UserGroup,
+			permission.Policy.ResourceGroup,
+			permission.Policy.Permission,
+			string(permission.Policy.Effect),
+		})
+	}
+
+	err := writer.WriteAll(records)
+	if err != nil {
+		return fmt.Errorf("failed to write CSV report: %w", err)
+	}
+
+	return nil
+}
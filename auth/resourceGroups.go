@@ -1,6 +1,10 @@
 //nolint:dupl // Duplicated code is reduced to a minimum with groupManager
 package auth
 
+import (
+	"slices"
+)
+
 type ResourceGroup struct {
 	ResourceName string
 	GroupName    string
@@ -9,34 +13,80 @@ type ResourceGroup struct {
 // CreateResourceGroup creates a new resource group with the specified name.
 // If the group already exists, the function returns without error.
 func (auth *AuthModule) CreateResourceGroup(groupName string) error {
-	return auth.resourceGroupManager.CreateGroup(groupName)
+	return auth.CreateResourceGroupWithInfo(GroupInfo{Name: groupName})
+}
+
+// CreateResourceGroupWithInfo creates the resource group info.Name with its
+// metadata, created now unless info.CreatedAt is set. If the group already
+// exists, its metadata is kept and the function returns without error.
+func (auth *AuthModule) CreateResourceGroupWithInfo(info GroupInfo) error {
+	return auth.mutate(func() (mutation, error) {
+		return auth.resourceGroupManager.CreateGroup(info)
+	})
+}
+
+// GetResourceGroupInfo returns the metadata of a resource group.
+func (auth *AuthModule) GetResourceGroupInfo(
+	groupName string,
+) (GroupInfo, error) {
+	defer auth.rlock()()
+
+	return auth.resourceGroupManager.GetInfo(groupName)
+}
+
+// UpdateResourceGroupInfo replaces the description and owner of the resource
+// group info.Name. Its creation time and creator cannot be changed.
+func (auth *AuthModule) UpdateResourceGroupInfo(info GroupInfo) error {
+	return auth.mutate(func() (mutation, error) {
+		return auth.resourceGroupManager.UpdateInfo(info)
+	})
 }
 
 // RemoveResourceGroup removes a resource group and all associated policies.
-// It prevents removal of the enclaveAdmin group to maintain system security.
+// Protected groups cannot be removed, see Protect.
 func (auth *AuthModule) RemoveResourceGroup(groupName string) error {
-	return auth.resourceGroupManager.RemoveGroup(groupName)
+	return auth.mutate(func() (mutation, error) {
+		return auth.resourceGroupManager.RemoveGroup(groupName)
+	})
 }
 
-// GetResourceGroups returns all resource groups as a slice of ResourceGroup
-// structs.
+// GetResourceGroups returns the resources of all resource groups as a slice of
+// ResourceGroup structs, sorted by group and resource. Groups without
+// resources are not included, see ListResourceGroupsPage.
 func (auth *AuthModule) GetResourceGroups() ([]ResourceGroup, error) {
-	return auth.resourceGroupManager.GetGroups()
+	defer auth.rlock()()
+
+	groups, err := auth.resourceGroupManager.GetGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	return sortMembers(groups), nil
 }
 
 // ResourceGroupExists checks if a resource group with the specified name
 // exists.
 func (auth *AuthModule) ResourceGroupExists(groupName string) (bool, error) {
+	defer auth.rlock()()
+
 	return auth.resourceGroupManager.GroupExists(groupName)
 }
 
 // AddResourceToGroup adds a resource to one or more groups.
 // It validates that all specified groups exist before adding the resource.
+// The resource has to be a valid pattern, see ValidateResourcePattern.
 func (auth *AuthModule) AddResourceToGroup(
 	resourceName string,
 	groupName ...string,
 ) error {
-	return auth.resourceGroupManager.AddToGroup(resourceName, groupName...)
+	err := ValidateResourcePattern(resourceName)
+	if err != nil {
+		return err
+	}
+
+	return auth.mutate(func() (mutation, error) {
+		return auth.resourceGroupManager.AddToGroup(resourceName, groupName...)
+	})
 }
 
 // RemoveResourceFromGroup removes a resource from one or more groups.
@@ -45,22 +95,36 @@ func (auth *AuthModule) RemoveResourceFromGroup(
 	resourceName string,
 	groupName ...string,
 ) error {
-	return auth.resourceGroupManager.RemoveFromGroup(resourceName, groupName...)
+	return auth.mutate(func() (mutation, error) {
+		return auth.resourceGroupManager.RemoveFromGroup(resourceName, groupName...)
+	})
 }
 
 // RemoveResource removes a resource from all groups it belongs to.
 func (auth *AuthModule) RemoveResource(resourceName string) error {
-	return auth.resourceGroupManager.RemoveEntity(resourceName)
+	return auth.mutate(func() (mutation, error) {
+		return auth.resourceGroupManager.RemoveEntity(resourceName)
+	})
 }
 
 // GetGroupsForResource returns all groups that a specific resource belongs to.
 func (auth *AuthModule) GetGroupsForResource(
 	resourceName string,
 ) ([]string, error) {
+	defer auth.rlock()()
+
 	return auth.resourceGroupManager.GetGroupsForEntity(resourceName)
 }
 
 // GetResourceGroup returns all resources that belong to a specific group.
 func (auth *AuthModule) GetResourceGroup(groupName string) ([]string, error) {
-	return auth.resourceGroupManager.GetEntitiesInGroup(groupName)
+	defer auth.rlock()()
+
+	resources, err := auth.resourceGroupManager.GetEntitiesInGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(resources)
+
+	return resources, nil
 }
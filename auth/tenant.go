@@ -0,0 +1,368 @@
+package auth
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// TenantSeparator separates the tenant from the name of a tenant scoped user
+// or resource group, e.g. "acme::developers". Policies naming a tenant scoped
+// group only apply to requests of that tenant, all other policies apply to
+// every tenant.
+const TenantSeparator = "::"
+
+// tenantAdminName is the local name of the admin group of every tenant.
+const tenantAdminName = "admin"
+
+// TenantResolver returns the tenant of a request, or "" if it has none.
+type TenantResolver func(r *http.Request) string
+
+// WithTenantResolver makes the Middleware resolve the tenant of every request
+// with resolver and store it in the request context, see SetTenant.
+func WithTenantResolver(resolver TenantResolver) Option {
+	return func(auth *AuthModule) {
+		auth.tenantResolver = resolver
+	}
+}
+
+// TenantFromHeader resolves the tenant from the request header name.
+func TenantFromHeader(name string) TenantResolver {
+	return func(r *http.Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// TenantFromPathSegment resolves the tenant from the path segment at index,
+// counting from zero and ignoring the leading slash. E.g. index 1 resolves
+// "acme" from "/v1/acme/runs".
+func TenantFromPathSegment(index int) TenantResolver {
+	return func(r *http.Request) string {
+		segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if index < 0 || index >= len(segments) {
+			return ""
+		}
+
+		return segments[index]
+	}
+}
+
+// TenantName returns the name of the group name scoped to tenant.
+func TenantName(tenant, name string) string {
+	return tenant + TenantSeparator + name
+}
+
+// SplitTenant splits a group name into its tenant and the name within the
+// tenant. The tenant is "" for groups that are not tenant scoped.
+func SplitTenant(name string) (string, string) {
+	tenant, local, ok := strings.Cut(name, TenantSeparator)
+	if !ok {
+		return "", name
+	}
+
+	return tenant, local
+}
+
+// TenantAdminGroup returns the name of the admin group of tenant. Its members
+// have full access to the resource groups of the tenant, but not to global
+// resources.
+func TenantAdminGroup(tenant string) string {
+	return TenantName(tenant, tenantAdminName)
+}
+
+// tenantAdminPolicy grants the admin group of tenant full access to the
+// resource groups of the tenant. It is only removed together with the tenant.
+func tenantAdminPolicy(tenant string) Policy {
+	return Policy{
+		TenantAdminGroup(tenant),
+		TenantName(tenant, "*"),
+		"*",
+		EffectAllow,
+	}
+}
+
+// isTenantWildcard reports whether name is the resource group "<tenant>::*",
+// which policies name to cover all resource groups of the tenant.
+func isTenantWildcard(name string) bool {
+	tenant, local := SplitTenant(name)
+
+	return tenant != "" && local == "*"
+}
+
+// coversResourceGroup reports whether a policy naming the resource group
+// pattern covers group. "*" covers all groups and "<tenant>::*" all groups of
+// the tenant.
+func coversResourceGroup(pattern, group string) bool {
+	if pattern == "*" || pattern == group {
+		return true
+	}
+	tenant, _ := SplitTenant(pattern)
+	groupTenant, _ := SplitTenant(group)
+
+	return isTenantWildcard(pattern) && groupTenant == tenant
+}
+
+// coversResourceGroups reports whether the policy covers one of the resource
+// groups groups, or all resource groups.
+func (p Policy) coversResourceGroups(groups []string) bool {
+	return p.ResourceGroup == "*" ||
+		slices.ContainsFunc(groups, func(group string) bool {
+			return coversResourceGroup(p.ResourceGroup, group)
+		})
+}
+
+// isTenantAdminGroup reports whether groupName is the admin group of a tenant.
+func isTenantAdminGroup(groupName string) bool {
+	tenant, local := SplitTenant(groupName)
+
+	return tenant != "" && local == tenantAdminName
+}
+
+// tenant returns the tenant the policy is scoped to, "" if it applies to all
+// tenants. Both groups of a policy have to belong to the same tenant.
+func (p Policy) tenant() (string, error) {
+	subjectTenant, _ := SplitTenant(p.UserGroup)
+	objectTenant, _ := SplitTenant(p.ResourceGroup)
+	if subjectTenant != "" && objectTenant != "" &&
+		subjectTenant != objectTenant {
+		return "", &ValidationError{fmt.Sprintf(
+			"user group %q and resource group %q belong to different tenants",
+			p.UserGroup,
+			p.ResourceGroup,
+		)}
+	}
+
+	return cmp.Or(subjectTenant, objectTenant), nil
+}
+
+// appliesIn reports whether the policy applies to requests of tenant.
+func (p Policy) appliesIn(tenant string) bool {
+	policyTenant, err := p.tenant()
+
+	return err == nil && (policyTenant == "" || policyTenant == tenant)
+}
+
+// inTenantFunc is the casbin matcher function inTenant(r.dom, p.sub, p.obj),
+// which reports whether a policy applies to requests of the tenant r.dom.
+func inTenantFunc(args ...any) (any, error) {
+	//nolint:mnd // Arguments of inTenant
+	if len(args) != 3 {
+		return false, nil
+	}
+	tenant, _ := args[0].(string)
+	subject, _ := args[1].(string)
+	object, _ := args[2].(string)
+
+	return Policy{UserGroup: subject, ResourceGroup: object}.
+		appliesIn(tenant), nil
+}
+
+// validateTenant rejects tenant names that cannot be told apart from group
+// names or wildcards.
+func validateTenant(tenant string) error {
+	if tenant == "" || tenant == "*" ||
+		strings.Contains(tenant, TenantSeparator) ||
+		strings.Contains(tenant, "/") {
+		return &ValidationError{fmt.Sprintf("invalid tenant name %q", tenant)}
+	}
+
+	return nil
+}
+
+// tenantExists reports whether the admin group of tenant exists.
+func tenantExists(enforcer *casbin.Enforcer, tenant string) (bool, error) {
+	registered, err := enforcer.GetFilteredNamedPolicy(
+		groupRegistryType,
+		0,
+		string(UserGroupType),
+		TenantAdminGroup(tenant),
+	)
+	if err != nil {
+		return false, &CasbinError{"GetFilteredNamedPolicy", err}
+	}
+
+	return len(registered) > 0, nil
+}
+
+// checkTenant returns a NotFoundError if groupName is scoped to a tenant that
+// does not exist.
+func checkTenant(enforcer *casbin.Enforcer, groupName string) error {
+	tenant, _ := SplitTenant(groupName)
+	if tenant == "" {
+		return nil
+	}
+
+	exists, err := tenantExists(enforcer, tenant)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &NotFoundError{"tenant", tenant}
+	}
+
+	return nil
+}
+
+// CreateTenant creates tenant together with its admin group, see
+// TenantAdminGroup. Groups scoped to the tenant can be created afterwards. If
+// the tenant already exists, the function returns without error.
+func (auth *AuthModule) CreateTenant(tenant string) error {
+	err := validateTenant(tenant)
+	if err != nil {
+		return err
+	}
+
+	return auth.mutate(func() (mutation, error) {
+		exists, err := tenantExists(auth.enforcer, tenant)
+		if err != nil {
+			return mutation{}, err
+		}
+
+		m := mutation{action: "CreateTenant"}
+		if !exists {
+			admins := GroupInfo{Name: TenantAdminGroup(tenant), CreatedAt: time.Now()}
+			m.add(groupRegistryType, admins.rule(UserGroupType)...)
+		}
+		m.add("p", tenantAdminPolicy(tenant).rule()...)
+
+		return m, nil
+	})
+}
+
+// RemoveTenant removes tenant with all its user groups, resource groups and
+// the policies referencing them.
+func (auth *AuthModule) RemoveTenant(tenant string) error {
+	err := validateTenant(tenant)
+	if err != nil {
+		return err
+	}
+
+	return auth.mutate(func() (mutation, error) {
+		exists, err := tenantExists(auth.enforcer, tenant)
+		if err != nil {
+			return mutation{}, err
+		}
+		if !exists {
+			return mutation{}, &NotFoundError{"tenant", tenant}
+		}
+
+		m := mutation{action: "RemoveTenant"}
+		userGroups, err := auth.userGroupManager.GroupNames()
+		if err != nil {
+			return mutation{}, err
+		}
+		for _, groupName := range tenantGroups(userGroups, tenant) {
+			removal, err := auth.userGroupManager.RemoveGroup(groupName)
+			if err != nil {
+				return mutation{}, err
+			}
+			m.changes = append(m.changes, removal.changes...)
+		}
+
+		resourceGroups, err := auth.resourceGroupManager.GroupNames()
+		if err != nil {
+			return mutation{}, err
+		}
+		for _, groupName := range tenantGroups(resourceGroups, tenant) {
+			removal, err := auth.resourceGroupManager.RemoveGroup(groupName)
+			if err != nil {
+				return mutation{}, err
+			}
+			m.changes = append(m.changes, removal.changes...)
+		}
+
+		return m, nil
+	})
+}
+
+// ListTenants returns the sorted names of all tenants.
+func (auth *AuthModule) ListTenants() ([]string, error) {
+	defer auth.rlock()()
+
+	userGroups, err := auth.userGroupManager.GroupNames()
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := []string{}
+	for _, group := range userGroups {
+		if isTenantAdminGroup(group) {
+			tenant, _ := SplitTenant(group)
+			tenants = append(tenants, tenant)
+		}
+	}
+	slices.Sort(tenants)
+
+	return tenants, nil
+}
+
+// IsTenantAdmin reports whether user is a member of the admin group of tenant
+// or of enclave_admin, whose members administrate all tenants.
+func (auth *AuthModule) IsTenantAdmin(tenant, user string) (bool, error) {
+	defer auth.rlock()()
+
+	groups, err := auth.groupsInEffect(user, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(groups, enclaveAdminGroup) ||
+		slices.Contains(groups, TenantAdminGroup(tenant)), nil
+}
+
+// migrateTenantAdminPolicies scopes the policies of tenant admin groups that
+// were stored when they granted access to all resources, global ones included,
+// to the resource groups of their tenant.
+func migrateTenantAdminPolicies(enforcer *casbin.Enforcer) error {
+	rules, err := enforcer.GetPolicy()
+	if err != nil {
+		return &CasbinError{"GetPolicy", err}
+	}
+
+	migrated := 0
+	for _, rule := range slices.Clone(rules) {
+		policy := policyFromRule(rule)
+		if !isTenantAdminGroup(policy.UserGroup) ||
+			policy != (Policy{policy.UserGroup, "*", "*", EffectAllow}) {
+			continue
+		}
+
+		tenant, _ := SplitTenant(policy.UserGroup)
+		_, err = enforcer.RemovePolicy(rule)
+		if err != nil {
+			return &CasbinError{"RemovePolicy", err}
+		}
+		_, err = enforcer.AddPolicy(tenantAdminPolicy(tenant).rule())
+		if err != nil {
+			return &CasbinError{"AddPolicy", err}
+		}
+		migrated++
+	}
+
+	if migrated > 0 {
+		log.Info().
+			Int("policies", migrated).
+			Msg("Scoped tenant admin policies to their tenants")
+	}
+
+	return nil
+}
+
+// tenantGroups returns the names of the groups scoped to tenant.
+func tenantGroups(groups []string, tenant string) []string {
+	names := []string{}
+	for _, group := range groups {
+		groupTenant, _ := SplitTenant(group)
+		if groupTenant == tenant {
+			names = append(names, group)
+		}
+	}
+
+	return names
+}
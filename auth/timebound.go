@@ -0,0 +1,680 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/casbin/casbin/v3"
+	"github.com/casbin/casbin/v3/model"
+	"github.com/casbin/casbin/v3/rbac"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// policyBoundsType stores the Validity of time-bound policies as
+	// sub, obj, act, eft, nbf, exp.
+	policyBoundsType = "p2"
+	// membershipBoundsType stores the Validity of time-bound user group
+	// memberships as user, group, nbf, exp.
+	membershipBoundsType = "p3"
+	// unbounded marks an open side of a Validity in stored rules.
+	unbounded = "_"
+)
+
+// Validity limits the time a membership or policy is in effect. A zero
+// NotBefore or ExpiresAt leaves that side open.
+type Validity struct {
+	NotBefore time.Time `json:"notBefore,omitzero" yaml:"notBefore,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitzero" yaml:"expiresAt,omitempty"`
+}
+
+// TimeBoundMembership is a user group membership that is only in effect
+// within its Validity.
+type TimeBoundMembership struct {
+	Membership
+	Validity
+}
+
+// TimeBoundPolicy is a policy that is only in effect within its Validity.
+type TimeBoundPolicy struct {
+	Policy
+	Validity
+}
+
+// validate rejects validities that are open on both sides or never in effect
+// after now.
+func (v Validity) validate(now time.Time) error {
+	switch {
+	case v.NotBefore.IsZero() && v.ExpiresAt.IsZero():
+		return &ValidationError{"validity needs notBefore or expiresAt"}
+	case !v.ExpiresAt.IsZero() && !v.ExpiresAt.After(now):
+		return &ValidationError{"expiresAt must be in the future"}
+	case !v.NotBefore.IsZero() && !v.ExpiresAt.IsZero() &&
+		!v.ExpiresAt.After(v.NotBefore):
+		return &ValidationError{"expiresAt must be after notBefore"}
+	}
+
+	return nil
+}
+
+// inEffect reports whether now lies within the validity.
+func (v Validity) inEffect(now time.Time) bool {
+	return (v.NotBefore.IsZero() || !now.Before(v.NotBefore)) && !v.expired(now)
+}
+
+func (v Validity) expired(now time.Time) bool {
+	return !v.ExpiresAt.IsZero() && !now.Before(v.ExpiresAt)
+}
+
+func (v Validity) fields() []string {
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return unbounded
+		}
+
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+
+	return []string{format(v.NotBefore), format(v.ExpiresAt)}
+}
+
+func validityFromFields(fields []string) (Validity, error) {
+	parse := func(field string) (time.Time, error) {
+		if field == unbounded {
+			return time.Time{}, nil
+		}
+
+		return time.Parse(time.RFC3339Nano, field)
+	}
+
+	notBefore, err := parse(fields[0])
+	if err != nil {
+		return Validity{}, &ValidationError{
+			fmt.Sprintf("invalid notBefore %q", fields[0]),
+		}
+	}
+	expiresAt, err := parse(fields[1])
+	if err != nil {
+		return Validity{}, &ValidationError{
+			fmt.Sprintf("invalid expiresAt %q", fields[1]),
+		}
+	}
+
+	return Validity{notBefore, expiresAt}, nil
+}
+
+// timeBound is a stored time bound of the membership rule of ptype g or the
+// policy rule of ptype p.
+type timeBound struct {
+	ptype    string
+	rule     []string
+	validity Validity
+	stored   []string
+}
+
+func membershipBound(stored []string) (timeBound, error) {
+	if len(stored) != 4 {
+		return timeBound{}, &ValidationError{
+			fmt.Sprintf(
+				"%s rule %v must have four fields",
+				membershipBoundsType,
+				stored,
+			),
+		}
+	}
+	validity, err := validityFromFields(stored[2:])
+	if err != nil {
+		return timeBound{}, err
+	}
+
+	return timeBound{string(UserGroupType), stored[:2], validity, stored}, nil
+}
+
+func policyBound(stored []string) (timeBound, error) {
+	if len(stored) != 6 {
+		return timeBound{}, &ValidationError{
+			fmt.Sprintf("%s rule %v must have six fields", policyBoundsType, stored),
+		}
+	}
+	validity, err := validityFromFields(stored[4:])
+	if err != nil {
+		return timeBound{}, err
+	}
+
+	return timeBound{"p", stored[:4], validity, stored}, nil
+}
+
+func (b timeBound) boundsType() string {
+	if b.ptype == "p" {
+		return policyBoundsType
+	}
+
+	return membershipBoundsType
+}
+
+// boundsTypes are the ptypes of stored time bounds.
+var boundsTypes = []string{membershipBoundsType, policyBoundsType}
+
+func parseBound(ptype string, stored []string) (timeBound, error) {
+	if ptype == policyBoundsType {
+		return policyBound(stored)
+	}
+
+	return membershipBound(stored)
+}
+
+// timeBounds returns the stored time bounds of memberships and policies.
+func timeBounds(enforcer *casbin.Enforcer) ([]timeBound, error) {
+	var bounds []timeBound
+	for _, ptype := range boundsTypes {
+		rules, err := enforcer.GetNamedPolicy(ptype)
+		if err != nil {
+			return nil, &CasbinError{"GetNamedPolicy", err}
+		}
+		for _, rule := range rules {
+			bound, err := parseBound(ptype, rule)
+			if err != nil {
+				return nil, err
+			}
+			bounds = append(bounds, bound)
+		}
+	}
+
+	return bounds, nil
+}
+
+// AddTimeBoundUserToGroup adds a user to one or more groups like
+// AddUserToGroup, but only for the time given by validity. Decisions ignore
+// the membership before NotBefore and from ExpiresAt on, it is stored until
+// it is swept, see SweepTimeBounds. Adding an existing membership again
+// replaces its validity; adding it with AddUserToGroup makes it permanent.
+func (auth *AuthModule) AddTimeBoundUserToGroup(
+	userName string,
+	validity Validity,
+	groupName ...string,
+) error {
+	return auth.mutate(func() (mutation, error) {
+		now := time.Now()
+		err := validity.validate(now)
+		if err != nil {
+			return mutation{}, err
+		}
+
+		m, err := auth.userGroupManager.AddToGroup(userName, groupName...)
+		if err != nil {
+			return mutation{}, err
+		}
+		m.action = "AddTimeBoundUserToGroup"
+		for _, group := range groupName {
+			m.add(
+				membershipBoundsType,
+				append([]string{userName, group}, validity.fields()...)...,
+			)
+		}
+
+		return m, nil
+	})
+}
+
+// AddTimeBoundPolicy adds policy like AddPolicy or AddDenyPolicy, depending on
+// its effect, but only for the time given by validity. Like time-bound
+// memberships, decisions ignore it outside validity. Adding an existing policy
+// again replaces its validity; adding it with AddPolicy or AddDenyPolicy makes
+// it permanent.
+func (auth *AuthModule) AddTimeBoundPolicy(
+	policy Policy,
+	validity Validity,
+) error {
+	return auth.mutate(func() (mutation, error) {
+		now := time.Now()
+		err := validity.validate(now)
+		if err != nil {
+			return mutation{}, err
+		}
+
+		m, err := auth.planPolicy(policy)
+		if err != nil {
+			return mutation{}, err
+		}
+		m.action = "AddTimeBoundPolicy"
+		m.add(
+			policyBoundsType,
+			append(policy.rule(), validity.fields()...)...,
+		)
+
+		return m, nil
+	})
+}
+
+// ListTimeBoundMemberships returns all time-bound user group memberships,
+// including those not in effect yet.
+func (auth *AuthModule) ListTimeBoundMemberships() (
+	[]TimeBoundMembership,
+	error,
+) {
+	defer auth.rlock()()
+
+	rules, err := auth.enforcer.GetNamedPolicy(membershipBoundsType)
+	if err != nil {
+		return nil, &CasbinError{"GetNamedPolicy", err}
+	}
+
+	memberships := make([]TimeBoundMembership, 0, len(rules))
+	for _, rule := range rules {
+		bound, err := membershipBound(rule)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, TimeBoundMembership{
+			Membership{Group: bound.rule[1], Member: bound.rule[0]},
+			bound.validity,
+		})
+	}
+
+	return memberships, nil
+}
+
+// ListTimeBoundPolicies returns all time-bound policies, including those not
+// in effect yet.
+func (auth *AuthModule) ListTimeBoundPolicies() ([]TimeBoundPolicy, error) {
+	defer auth.rlock()()
+
+	return auth.listTimeBoundPolicies()
+}
+
+func (auth *AuthModule) listTimeBoundPolicies() ([]TimeBoundPolicy, error) {
+	rules, err := auth.enforcer.GetNamedPolicy(policyBoundsType)
+	if err != nil {
+		return nil, &CasbinError{"GetNamedPolicy", err}
+	}
+
+	policies := make([]TimeBoundPolicy, 0, len(rules))
+	for _, rule := range rules {
+		bound, err := policyBound(rule)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, TimeBoundPolicy{
+			policyFromRule(bound.rule),
+			bound.validity,
+		})
+	}
+
+	return policies, nil
+}
+
+// withTimeBounds inserts the removal of the stored time bounds of every
+// membership and policy m adds or removes right after that change, so adding
+// them without bounds makes them permanent and removing them leaves no bounds
+// behind. Removing a group removes the bounds referring to it as well.
+func (auth *AuthModule) withTimeBounds(m mutation) (mutation, error) {
+	bounds, err := timeBounds(auth.enforcer)
+	if err != nil || len(bounds) == 0 {
+		return m, err
+	}
+
+	changes := make([]ruleChange, 0, len(m.changes))
+	for _, change := range m.changes {
+		changes = append(changes, change)
+		for _, bound := range bounds {
+			if boundAffected(bound, change) {
+				changes = append(changes, ruleChange{
+					ptype:  bound.boundsType(),
+					rule:   bound.stored,
+					remove: true,
+				})
+			}
+		}
+	}
+	m.changes = changes
+
+	return m, nil
+}
+
+// boundAffected reports whether change adds or removes the rule bound applies
+// to or removes a group the rule refers to.
+func boundAffected(bound timeBound, change ruleChange) bool {
+	switch {
+	case change.ptype == bound.ptype && slices.Equal(change.rule, bound.rule):
+		return true
+	case !change.remove || change.ptype != groupRegistryType:
+		return false
+	case change.rule[0] == string(UserGroupType):
+		group := change.rule[1]
+		if bound.ptype == "p" {
+			return bound.rule[0] == group
+		}
+
+		return bound.rule[0] == group || bound.rule[1] == group
+	case change.rule[0] == string(ResourceGroupType):
+		return bound.ptype == "p" && bound.rule[1] == change.rule[1]
+	}
+
+	return false
+}
+
+// timeBoundEvent is an audit log entry of SweepTimeBounds.
+type timeBoundEvent struct {
+	message string
+	bound   timeBound
+}
+
+func (e timeBoundEvent) log() {
+	event := log.Info()
+	if e.bound.ptype == "p" {
+		event = event.
+			Str("userGroup", e.bound.rule[0]).
+			Str("resourceGroup", e.bound.rule[1]).
+			Str("permission", e.bound.rule[2]).
+			Str("effect", e.bound.rule[3])
+	} else {
+		event = event.
+			Str("user", e.bound.rule[0]).
+			Str("group", e.bound.rule[1])
+	}
+	if !e.bound.validity.NotBefore.IsZero() {
+		event = event.Time("notBefore", e.bound.validity.NotBefore)
+	}
+	if !e.bound.validity.ExpiresAt.IsZero() {
+		event = event.Time("expiresAt", e.bound.validity.ExpiresAt)
+	}
+	event.Str("audit", "timeBound").Msg(e.message)
+}
+
+// SweepTimeBounds removes expired time-bound memberships and policies together
+// with their bounds and persists the removal. Each removal is logged as an
+// audit event. Decisions ignore expired rules whether they are swept or not,
+// see WithTimeBoundSweeper to sweep regularly.
+func (auth *AuthModule) SweepTimeBounds() error {
+	var events []timeBoundEvent
+	err := auth.mutate(func() (mutation, error) {
+		var (
+			m   mutation
+			err error
+		)
+		m, events, err = auth.planSweep(time.Now())
+
+		return m, err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		event.log()
+	}
+
+	return nil
+}
+
+// planSweep plans the changes of SweepTimeBounds at now.
+func (auth *AuthModule) planSweep(
+	now time.Time,
+) (mutation, []timeBoundEvent, error) {
+	bounds, err := timeBounds(auth.enforcer)
+	if err != nil {
+		return mutation{}, nil, err
+	}
+
+	m := mutation{action: "SweepTimeBounds", keepBounds: true}
+	var events []timeBoundEvent
+	for _, bound := range bounds {
+		if !bound.validity.expired(now) {
+			continue
+		}
+		m.remove(bound.ptype, bound.rule...)
+		m.remove(bound.boundsType(), bound.stored...)
+		events = append(events, timeBoundEvent{
+			message: "Time-bound " + bound.kind() + " expired",
+			bound:   bound,
+		})
+	}
+
+	return m, events, nil
+}
+
+func (b timeBound) kind() string {
+	if b.ptype == "p" {
+		return "policy"
+	}
+
+	return "membership"
+}
+
+// migratePendingTimeBounds adds the rules of time bounds that were stored
+// without them, when rules were only added once their NotBefore passed.
+// Expired bounds are left to SweepTimeBounds.
+func migratePendingTimeBounds(enforcer *casbin.Enforcer) error {
+	bounds, err := timeBounds(enforcer)
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, bound := range bounds {
+		var added bool
+		if bound.ptype == "p" {
+			added, err = enforcer.AddPolicy(bound.rule)
+		} else {
+			added, err = enforcer.AddNamedGroupingPolicy(bound.ptype, bound.rule)
+		}
+		if err != nil {
+			return &CasbinError{"AddPolicy", err}
+		}
+		if added {
+			migrated++
+		}
+	}
+
+	if migrated > 0 {
+		log.Info().
+			Int("rules", migrated).
+			Msg("Added the rules of pending time bounds")
+	}
+
+	return nil
+}
+
+// validities returns the validity of every time-bound rule of ptype, keyed by
+// ruleKey.
+func validities(
+	enforcer *casbin.Enforcer,
+	ptype string,
+) (map[string]Validity, error) {
+	boundsType := membershipBoundsType
+	if ptype == "p" {
+		boundsType = policyBoundsType
+	}
+
+	rules, err := enforcer.GetNamedPolicy(boundsType)
+	if err != nil {
+		return nil, &CasbinError{"GetNamedPolicy", err}
+	}
+	validities := make(map[string]Validity, len(rules))
+	for _, rule := range rules {
+		bound, err := parseBound(boundsType, rule)
+		if err != nil {
+			return nil, err
+		}
+		validities[ruleKey(bound.ptype, bound.rule)] = bound.validity
+	}
+
+	return validities, nil
+}
+
+// inEffectFunc returns the casbin matcher function
+// inEffect(p.sub, p.obj, p.act, p.eft), which reports whether a policy is
+// within its time bounds. Policies without bounds are always in effect.
+func inEffectFunc(d *decisions) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		//nolint:mnd // Arguments of inEffect
+		if len(args) != 4 {
+			return false, nil
+		}
+		rule := make([]string, 0, len(args))
+		for _, arg := range args {
+			field, _ := arg.(string)
+			rule = append(rule, field)
+		}
+
+		index, err := d.load()
+		if err != nil {
+			return false, err
+		}
+
+		return index.policyInEffect(policyFromRule(rule), time.Now()), nil
+	}
+}
+
+// memberOfFunc returns the casbin matcher function memberOf(r.sub, p.sub),
+// which replaces g(r.sub, p.sub) and leaves out memberships outside their time
+// bounds.
+func memberOfFunc(d *decisions) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		//nolint:mnd // Arguments of memberOf
+		if len(args) != 2 {
+			return false, nil
+		}
+		user, _ := args[0].(string)
+		group, _ := args[1].(string)
+		if user == group {
+			return true, nil
+		}
+
+		index, err := d.load()
+		if err != nil {
+			return false, err
+		}
+		groups, err := index.groupsOf(user, time.Now())
+		if err != nil {
+			return false, err
+		}
+
+		return groups.set[group], nil
+	}
+}
+
+// linksInEffect follows the user group links from name and returns all names
+// reached through links in effect at now according to bounds. It follows
+// links to groups, or to members if reverse is set.
+func linksInEffect(
+	enforcer *casbin.Enforcer,
+	bounds map[string]Validity,
+	name string,
+	now time.Time,
+	reverse bool,
+) ([]string, error) {
+	next := rbac.RoleManager.GetRoles
+	if reverse {
+		next = rbac.RoleManager.GetUsers
+	}
+
+	roleManager := enforcer.GetRoleManager()
+	visited := map[string]bool{name: true}
+	queue := []string{name}
+	reached := []string{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		linked, err := next(roleManager, current)
+		if err != nil {
+			return nil, &CasbinError{"GetRoles", err}
+		}
+		for _, other := range linked {
+			link := []string{current, other}
+			if reverse {
+				link = []string{other, current}
+			}
+			validity, bounded := bounds[ruleKey(string(UserGroupType), link)]
+			if visited[other] || (bounded && !validity.inEffect(now)) {
+				continue
+			}
+			visited[other] = true
+			reached = append(reached, other)
+			queue = append(queue, other)
+		}
+	}
+
+	return reached, nil
+}
+
+// WithTimeBoundSweeper runs SweepTimeBounds every interval until Close is
+// called, so expired memberships and policies are removed from storage and
+// audited. Decisions never sweep, they judge time bounds on their own.
+func WithTimeBoundSweeper(interval time.Duration) Option {
+	return func(auth *AuthModule) {
+		auth.sweepInterval = interval
+	}
+}
+
+func (auth *AuthModule) startSweeper() {
+	ctx, cancel := context.WithCancel(context.Background())
+	auth.lifecycle.stopSweeper = cancel
+
+	go func() {
+		ticker := time.NewTicker(auth.sweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := auth.SweepTimeBounds()
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to sweep time bounds")
+				}
+			}
+		}
+	}()
+}
+
+// boundsChanges returns the changes that replace the live time bounds with
+// those of loaded.
+func (auth *AuthModule) boundsChanges(
+	loaded model.Model,
+) ([]ruleChange, error) {
+	var changes []ruleChange
+	for _, ptype := range boundsTypes {
+		want, err := loaded.GetPolicy("p", ptype)
+		if err != nil {
+			return nil, &CasbinError{"GetPolicy", err}
+		}
+		for _, rule := range want {
+			_, err = parseBound(ptype, rule)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		live, err := auth.enforcer.GetNamedPolicy(ptype)
+		if err != nil {
+			return nil, &CasbinError{"GetNamedPolicy", err}
+		}
+
+		for _, rule := range want {
+			if !slices.ContainsFunc(live, ruleEquals(rule)) {
+				changes = append(changes, ruleChange{ptype: ptype, rule: rule})
+			}
+		}
+		for _, rule := range live {
+			if !slices.ContainsFunc(want, ruleEquals(rule)) {
+				changes = append(
+					changes,
+					ruleChange{ptype: ptype, rule: rule, remove: true},
+				)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func ruleEquals(rule []string) func([]string) bool {
+	return func(other []string) bool {
+		return slices.Equal(rule, other)
+	}
+}
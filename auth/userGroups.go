@@ -1,30 +1,83 @@
 //nolint:dupl // Duplicated code is reduced to a minimum with groupManager
 package auth
 
+import (
+	"slices"
+)
+
 type UserGroup struct {
 	UserName  string
 	GroupName string
 }
 
+// NestedMembership separates direct memberships from those inherited through
+// nested user groups. Both lists are sorted.
+type NestedMembership struct {
+	Direct    []string `json:"direct"`
+	Inherited []string `json:"inherited"`
+}
+
 // CreateUserGroup creates a new user group with the specified name.
 // If the group already exists, the function returns without error.
 func (auth *AuthModule) CreateUserGroup(groupName string) error {
-	return auth.userGroupManager.CreateGroup(groupName)
+	return auth.CreateUserGroupWithInfo(GroupInfo{Name: groupName})
+}
+
+// CreateUserGroupWithInfo creates the user group info.Name with its metadata,
+// created now unless info.CreatedAt is set. If the group already exists, its
+// metadata is kept and the function returns without error.
+func (auth *AuthModule) CreateUserGroupWithInfo(info GroupInfo) error {
+	return auth.mutate(func() (mutation, error) {
+		return auth.userGroupManager.CreateGroup(info)
+	})
+}
+
+// GetUserGroupInfo returns the metadata of a user group.
+func (auth *AuthModule) GetUserGroupInfo(groupName string) (GroupInfo, error) {
+	defer auth.rlock()()
+
+	return auth.userGroupManager.GetInfo(groupName)
+}
+
+// UpdateUserGroupInfo replaces the description and owner of the user group
+// info.Name. Its creation time and creator cannot be changed.
+func (auth *AuthModule) UpdateUserGroupInfo(info GroupInfo) error {
+	return auth.mutate(func() (mutation, error) {
+		return auth.userGroupManager.UpdateInfo(info)
+	})
 }
 
 // RemoveUserGroup removes a user group and all associated policies.
-// It prevents removal of the enclaveAdmin group to maintain system security.
+// Protected groups, like enclaveAdmin, cannot be removed, see Protect.
+// The admin group of a tenant is only removed by RemoveTenant.
 func (auth *AuthModule) RemoveUserGroup(groupName string) error {
-	return auth.userGroupManager.RemoveGroup(groupName)
+	if isTenantAdminGroup(groupName) {
+		return &ConflictError{"Tenant admin group cannot be removed"}
+	}
+
+	return auth.mutate(func() (mutation, error) {
+		return auth.userGroupManager.RemoveGroup(groupName)
+	})
 }
 
-// GetUserGroups returns all user groups as a slice of UserGroup structs.
+// GetUserGroups returns the members of all user groups as a slice of
+// UserGroup structs, sorted by group and member. Groups without members are
+// not included, see ListUserGroupsPage.
 func (auth *AuthModule) GetUserGroups() ([]UserGroup, error) {
-	return auth.userGroupManager.GetGroups()
+	defer auth.rlock()()
+
+	groups, err := auth.userGroupManager.GetGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	return sortMembers(groups), nil
 }
 
 // UserGroupExists checks if a user group with the specified name exists.
 func (auth *AuthModule) UserGroupExists(groupName string) (bool, error) {
+	defer auth.rlock()()
+
 	return auth.userGroupManager.GroupExists(groupName)
 }
 
@@ -34,7 +87,30 @@ func (auth *AuthModule) AddUserToGroup(
 	userName string,
 	groupName ...string,
 ) error {
-	return auth.userGroupManager.AddToGroup(userName, groupName...)
+	return auth.mutate(func() (mutation, error) {
+		return auth.userGroupManager.AddToGroup(userName, groupName...)
+	})
+}
+
+// AddUserGroupToGroup makes memberGroup a member of one or more groups, so its
+// members inherit their permissions. Both groups have to exist and a group
+// cannot become a member of one of its own members; such cycles are rejected
+// with a ConflictError. Use RemoveUserFromGroup to remove it again.
+func (auth *AuthModule) AddUserGroupToGroup(
+	memberGroup string,
+	groupName ...string,
+) error {
+	return auth.mutate(func() (mutation, error) {
+		exists, err := auth.userGroupManager.GroupExists(memberGroup)
+		if err != nil {
+			return mutation{}, err
+		}
+		if !exists {
+			return mutation{}, &NotFoundError{"userGroup", memberGroup}
+		}
+
+		return auth.userGroupManager.AddToGroup(memberGroup, groupName...)
+	})
 }
 
 // RemoveUserFromGroup removes a user from one or more groups.
@@ -43,20 +119,102 @@ func (auth *AuthModule) RemoveUserFromGroup(
 	userName string,
 	groupName ...string,
 ) error {
-	return auth.userGroupManager.RemoveFromGroup(userName, groupName...)
+	return auth.mutate(func() (mutation, error) {
+		return auth.userGroupManager.RemoveFromGroup(userName, groupName...)
+	})
 }
 
-// RemoveUser removes a user from all groups they belong to.
+// RemoveUser removes a user from all groups they belong to, including
+// time-bound memberships that are not in effect yet.
 func (auth *AuthModule) RemoveUser(userName string) error {
-	return auth.userGroupManager.RemoveEntity(userName)
+	return auth.mutate(func() (mutation, error) {
+		m, err := auth.userGroupManager.RemoveEntity(userName)
+		if err != nil {
+			return mutation{}, err
+		}
+
+		memberships, err := auth.enforcer.GetFilteredNamedPolicy(
+			membershipBoundsType,
+			0,
+			userName,
+		)
+		if err != nil {
+			return mutation{}, &CasbinError{"RemoveUser", err}
+		}
+		for _, membership := range memberships {
+			m.remove(membershipBoundsType, membership...)
+		}
+
+		return m, nil
+	})
 }
 
-// GetGroupsForUser returns all groups that a specific user belongs to.
+// GetGroupsForUser returns all groups that a specific user or user group
+// directly belongs to.
 func (auth *AuthModule) GetGroupsForUser(userName string) ([]string, error) {
+	defer auth.rlock()()
+
 	return auth.userGroupManager.GetGroupsForEntity(userName)
 }
 
-// GetUserGroup returns all users that belong to a specific group.
+// GetUserGroup returns all users and user groups that directly belong to a
+// specific group.
 func (auth *AuthModule) GetUserGroup(groupName string) ([]string, error) {
-	return auth.userGroupManager.GetEntitiesInGroup(groupName)
+	defer auth.rlock()()
+
+	members, err := auth.userGroupManager.GetEntitiesInGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(members)
+
+	return members, nil
+}
+
+// GetUserMemberships returns the groups a user or user group belongs to,
+// directly and through nested groups.
+func (auth *AuthModule) GetUserMemberships(
+	userName string,
+) (NestedMembership, error) {
+	defer auth.rlock()()
+
+	direct, err := auth.userGroupManager.GetGroupsForEntity(userName)
+	if err != nil {
+		return NestedMembership{}, err
+	}
+	inherited, err := auth.userGroupManager.ancestors(userName)
+	if err != nil {
+		return NestedMembership{}, err
+	}
+
+	return newNestedMembership(direct, inherited), nil
+}
+
+// GetUserGroupMembers returns the members of a user group, directly and
+// through nested groups.
+func (auth *AuthModule) GetUserGroupMembers(
+	groupName string,
+) (NestedMembership, error) {
+	defer auth.rlock()()
+
+	direct, err := auth.userGroupManager.GetEntitiesInGroup(groupName)
+	if err != nil {
+		return NestedMembership{}, err
+	}
+	inherited, err := auth.userGroupManager.descendants(groupName)
+	if err != nil {
+		return NestedMembership{}, err
+	}
+
+	return newNestedMembership(direct, inherited), nil
+}
+
+func newNestedMembership(direct, inherited []string) NestedMembership {
+	membership := NestedMembership{
+		Direct:    append([]string{}, direct...),
+		Inherited: append([]string{}, inherited...),
+	}
+	slices.Sort(membership.Direct)
+
+	return membership
 }
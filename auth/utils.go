@@ -6,7 +6,10 @@ import (
 
 type contextKey string
 
-const authenticatedUser contextKey = "authenticatedUser"
+const (
+	authenticatedUser contextKey = "authenticatedUser"
+	tenantKey         contextKey = "tenant"
+)
 
 func SetAuthenticatedUser(ctx context.Context, user string) context.Context {
 	return context.WithValue(ctx, authenticatedUser, user)
@@ -20,3 +23,16 @@ func GetAuthenticatedUser(ctx context.Context) string {
 
 	return user
 }
+
+// SetTenant stores the tenant of a request in ctx. Check and Require enforce
+// within this tenant.
+func SetTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+// GetTenant returns the tenant stored with SetTenant, "" if there is none.
+func GetTenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey).(string)
+
+	return tenant
+}
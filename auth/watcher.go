@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/casbin/casbin/v3/persist"
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// WithWatcher keeps several AuthModules on a shared adapter in sync, e.g. the
+// replicas of a service. After every committed change the module notifies
+// watcher, and it reloads all policies from the adapter when watcher reports
+// a change of another module. Any casbin watcher can be used; MemoryBroadcaster
+// and FileWatcher are provided for tests and single host setups.
+func WithWatcher(watcher persist.Watcher) Option {
+	return func(auth *AuthModule) {
+		auth.lifecycle.watcher = watcher
+	}
+}
+
+// reload replaces the in-memory policies with those of the adapter.
+func (auth *AuthModule) reload() {
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+
+	err := auth.enforcer.LoadPolicy()
+	auth.decisions.invalidate()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to reload casbin policy")
+
+		return
+	}
+
+	log.Debug().Msg("Reloaded casbin policy after change notification")
+}
+
+// notifyWatcher tells the other modules about a committed change. The change
+// is already persisted, so a failed notification is only logged.
+func (auth *AuthModule) notifyWatcher() {
+	watcher := auth.lifecycle.watcher
+	if watcher == nil {
+		return
+	}
+
+	err := watcher.Update()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to notify watcher about policy change")
+	}
+}
+
+// MemoryBroadcaster connects the watchers of AuthModules within one process.
+// Update of one watcher synchronously calls the callbacks of all others.
+type MemoryBroadcaster struct {
+	mu       sync.Mutex
+	watchers map[*memoryWatcher]struct{}
+}
+
+func NewMemoryBroadcaster() *MemoryBroadcaster {
+	return &MemoryBroadcaster{watchers: map[*memoryWatcher]struct{}{}}
+}
+
+// NewWatcher returns a watcher connected to all other watchers of b.
+func (b *MemoryBroadcaster) NewWatcher() persist.Watcher {
+	watcher := &memoryWatcher{broadcaster: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.watchers[watcher] = struct{}{}
+
+	return watcher
+}
+
+func (b *MemoryBroadcaster) broadcast(sender *memoryWatcher) {
+	b.mu.Lock()
+	callbacks := make([]func(string), 0, len(b.watchers))
+	for watcher := range b.watchers {
+		if watcher != sender && watcher.callback != nil {
+			callbacks = append(callbacks, watcher.callback)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback("")
+	}
+}
+
+type memoryWatcher struct {
+	broadcaster *MemoryBroadcaster
+	callback    func(string)
+}
+
+func (w *memoryWatcher) SetUpdateCallback(callback func(string)) error {
+	w.broadcaster.mu.Lock()
+	defer w.broadcaster.mu.Unlock()
+	w.callback = callback
+
+	return nil
+}
+
+func (w *memoryWatcher) Update() error {
+	w.broadcaster.broadcast(w)
+
+	return nil
+}
+
+func (w *memoryWatcher) Close() {
+	w.broadcaster.mu.Lock()
+	defer w.broadcaster.mu.Unlock()
+	delete(w.broadcaster.watchers, w)
+}
+
+// FileWatcher connects AuthModules through a file, e.g. on a volume shared by
+// all replicas. Update writes a new token to the file and all other
+// FileWatchers of that file call their callback once they see it change.
+type FileWatcher struct {
+	path     string
+	id       string
+	counter  atomic.Uint64
+	watcher  *fsnotify.Watcher
+	callback atomic.Pointer[func(string)]
+}
+
+// NewFileWatcher watches path, which is created on the first Update if it does
+// not exist. Its directory must exist.
+func NewFileWatcher(path string) (*FileWatcher, error) {
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return nil, fmt.Errorf("generate watcher id: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create file watcher: %w", err)
+	}
+
+	// Watch the directory, the file is replaced on every update
+	err = watcher.Add(filepath.Dir(path))
+	if err != nil {
+		_ = watcher.Close()
+
+		return nil, fmt.Errorf("watch %s: %w", filepath.Dir(path), err)
+	}
+
+	fileWatcher := &FileWatcher{
+		path:    filepath.Clean(path),
+		id:      fmt.Sprintf("%x", id),
+		watcher: watcher,
+	}
+	go fileWatcher.run()
+
+	return fileWatcher, nil
+}
+
+func (w *FileWatcher) SetUpdateCallback(callback func(string)) error {
+	w.callback.Store(&callback)
+
+	return nil
+}
+
+func (w *FileWatcher) Update() error {
+	token := fmt.Sprintf("%s %d", w.id, w.counter.Add(1))
+
+	// Write and rename, so watchers never read a partially written token
+	tmp := fmt.Sprintf("%s.%s.tmp", w.path, w.id)
+	err := os.WriteFile(tmp, []byte(token), 0o600)
+	if err != nil {
+		return fmt.Errorf("write watcher token: %w", err)
+	}
+	err = os.Rename(tmp, w.path)
+	if err != nil {
+		return fmt.Errorf("replace watcher file: %w", err)
+	}
+
+	return nil
+}
+
+func (w *FileWatcher) Close() {
+	_ = w.watcher.Close()
+}
+
+func (w *FileWatcher) run() {
+	lastToken := ""
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != w.path ||
+				!event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+				continue
+			}
+
+			content, err := os.ReadFile(w.path)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to read watcher file")
+
+				continue
+			}
+
+			token := string(content)
+			if token == lastToken || strings.HasPrefix(token, w.id+" ") {
+				continue
+			}
+			lastToken = token
+
+			if callback := w.callback.Load(); callback != nil {
+				(*callback)(token)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Error().Err(err).Msg("File watcher failed")
+		}
+	}
+}
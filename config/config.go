@@ -14,10 +14,32 @@ import (
 )
 
 type BaseConfig struct {
-	HumanReadableOutput   bool   `mapstructure:"human_readable_output"  validate:""`
-	LogLevel              string `mapstructure:"log_level"              validate:"oneof=debug info warn error"`
-	ProductionEnvironment bool   `mapstructure:"production_environment" validate:""`
-	Port                  int    `mapstructure:"port"                   validate:"numeric,min=1,max=65535"`
+	HumanReadableOutput   bool       `mapstructure:"human_readable_output"  validate:""`
+	LogLevel              string     `mapstructure:"log_level"              validate:"oneof=debug info warn error"`
+	ProductionEnvironment bool       `mapstructure:"production_environment" validate:""`
+	Port                  int        `mapstructure:"port"                   validate:"numeric,min=1,max=65535"`
+	Auth                  AuthConfig `mapstructure:"auth"                   validate:""`
+}
+
+// AuthConfig declares user groups, resource groups and policies every
+// instance of the service starts with.
+type AuthConfig struct {
+	UserGroups     []GroupConfig  `mapstructure:"user_groups"     validate:"dive"`
+	ResourceGroups []GroupConfig  `mapstructure:"resource_groups" validate:"dive"`
+	Policies       []PolicyConfig `mapstructure:"policies"        validate:"dive"`
+}
+
+type GroupConfig struct {
+	Name    string   `mapstructure:"name"    validate:"required"`
+	Members []string `mapstructure:"members" validate:"dive,required"`
+}
+
+type PolicyConfig struct {
+	UserGroup     string `mapstructure:"user_group"     validate:"required"`
+	ResourceGroup string `mapstructure:"resource_group" validate:"required"`
+	Permission    string `mapstructure:"permission"     validate:"required"`
+	// Effect is "allow" or "deny", allow if empty.
+	Effect string `mapstructure:"effect"         validate:"omitempty,oneof=allow deny"`
 }
 
 type HasBaseConfig interface {
@@ -343,3 +343,74 @@ func clearEnv(t *testing.T) {
 	// Reset log level to info for consistency
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 }
+
+func TestLoadAppConfig_WithAuthSection(t *testing.T) {
+	clearEnv(t)
+
+	tmpDir := t.TempDir()
+	configContent := `
+auth:
+  user_groups:
+    - name: Runners
+      members: [alice]
+  resource_groups:
+    - name: runs
+      members: [/runs/*]
+  policies:
+    - user_group: Runners
+      resource_group: runs
+      permission: GET
+`
+	configPath := filepath.Join(tmpDir, "test-service.yml")
+	err := os.WriteFile(configPath, []byte(configContent), 0o644)
+	require.NoError(t, err)
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:errcheck // defer in test
+	defer os.Chdir(originalDir)
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err)
+
+	config := &MinimalConfig{}
+	err = PopulateAppConfig(config, "test-service", "1.0.0")
+
+	require.NoError(t, err)
+	assert.Equal(t, AuthConfig{
+		UserGroups: []GroupConfig{{Name: "Runners", Members: []string{"alice"}}},
+		ResourceGroups: []GroupConfig{
+			{Name: "runs", Members: []string{"/runs/*"}},
+		},
+		Policies: []PolicyConfig{
+			{UserGroup: "Runners", ResourceGroup: "runs", Permission: "GET"},
+		},
+	}, config.Auth)
+}
+
+func TestLoadAppConfig_InvalidAuthPolicy(t *testing.T) {
+	clearEnv(t)
+
+	tmpDir := t.TempDir()
+	configContent := `
+auth:
+  policies:
+    - user_group: runners
+      resource_group: runs
+`
+	configPath := filepath.Join(tmpDir, "test-service.yml")
+	err := os.WriteFile(configPath, []byte(configContent), 0o644)
+	require.NoError(t, err)
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:errcheck // defer in test
+	defer os.Chdir(originalDir)
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err)
+
+	config := &MinimalConfig{}
+	err = PopulateAppConfig(config, "test-service", "1.0.0")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Permission")
+}
@@ -0,0 +1,655 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/EnclaveRunner/shareddeps/auth"
+	"github.com/EnclaveRunner/shareddeps/middleware"
+	pb "github.com/EnclaveRunner/shareddeps/proto_gen"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuthAdminServer implements the AuthAdminService on top of an AuthModule.
+// Callers authenticate with BasicAuth credentials in the authorization
+// metadata and must be members of enclave_admin. The methods do not check this
+// themselves, the server has to be served with its UnaryInterceptor.
+type AuthAdminServer struct {
+	pb.UnimplementedAuthAdminServiceServer
+
+	authModule    *auth.AuthModule
+	authenticator middleware.BasicAuthenticator
+}
+
+// authAdminMethodPrefix is the prefix of the full method names of the
+// AuthAdminService.
+var authAdminMethodPrefix = "/" + pb.AuthAdminService_ServiceDesc.ServiceName + "/"
+
+// callerKey is the context key of the caller authorized by the interceptor.
+type callerKey struct{}
+
+func NewAuthAdminServer(
+	authModule *auth.AuthModule,
+	authenticator middleware.BasicAuthenticator,
+) *AuthAdminServer {
+	return &AuthAdminServer{
+		authModule:    authModule,
+		authenticator: authenticator,
+	}
+}
+
+// ListUserGroups implements AuthAdminServiceServer.
+func (s *AuthAdminServer) ListUserGroups(
+	ctx context.Context,
+	in *pb.ListRequest,
+) (*pb.GroupNames, error) {
+	page, err := s.authModule.ListUserGroupsPage(
+		toListOptions(in.GetOptions()),
+	)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GroupNames{Names: page.Items, NextCursor: page.NextCursor}, nil
+}
+
+// CreateUserGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) CreateUserGroup(
+	ctx context.Context,
+	in *pb.GroupInfo,
+) (*emptypb.Empty, error) {
+	user := caller(ctx)
+	if in.GetName() == "" {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			"group name must not be empty",
+		)
+	}
+
+	info := toGroupInfo(in)
+	if user != auth.UnauthenticatedUser {
+		info.CreatedBy = user
+	}
+
+	return empty(s.authModule.CreateUserGroupWithInfo(info))
+}
+
+// GetUserGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) GetUserGroup(
+	ctx context.Context,
+	in *pb.GroupQuery,
+) (*pb.Group, error) {
+	page, err := s.authModule.GetUserGroupPage(
+		in.GetName(),
+		toListOptions(in.GetOptions()),
+	)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	members, err := s.authModule.GetUserGroupMembers(in.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	info, err := s.authModule.GetUserGroupInfo(in.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.Group{
+		Name:             in.GetName(),
+		Members:          page.Items,
+		InheritedMembers: members.Inherited,
+		NextCursor:       page.NextCursor,
+		Info:             fromGroupInfo(info),
+	}, nil
+}
+
+// UpdateUserGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) UpdateUserGroup(
+	ctx context.Context,
+	in *pb.UpdateGroupRequest,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.Update(func(tx *auth.Tx) error {
+		info, err := tx.GetUserGroupInfo(in.GetName())
+		if err != nil {
+			return err
+		}
+
+		return tx.UpdateUserGroupInfo(updateInfo(info, in))
+	}))
+}
+
+// DeleteUserGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) DeleteUserGroup(
+	ctx context.Context,
+	in *pb.GroupName,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.RemoveUserGroup(in.GetName()))
+}
+
+// AddUserToGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) AddUserToGroup(
+	ctx context.Context,
+	in *pb.Membership,
+) (*emptypb.Empty, error) {
+	validity, bounded := toValidity(in.GetNotBefore(), in.GetExpiresAt())
+	if bounded {
+		return empty(s.authModule.AddTimeBoundUserToGroup(
+			in.GetMember(),
+			validity,
+			in.GetGroup(),
+		))
+	}
+
+	return empty(s.authModule.AddUserToGroup(in.GetMember(), in.GetGroup()))
+}
+
+// RemoveUserFromGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) RemoveUserFromGroup(
+	ctx context.Context,
+	in *pb.Membership,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.RemoveUserFromGroup(in.GetMember(), in.GetGroup()))
+}
+
+// RemoveUser implements AuthAdminServiceServer.
+func (s *AuthAdminServer) RemoveUser(
+	ctx context.Context,
+	in *pb.Member,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.RemoveUser(in.GetName()))
+}
+
+// GetGroupsForUser implements AuthAdminServiceServer.
+func (s *AuthAdminServer) GetGroupsForUser(
+	ctx context.Context,
+	in *pb.Member,
+) (*pb.GroupNames, error) {
+	groups, err := s.authModule.GetGroupsForUser(in.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GroupNames{Names: groups}, nil
+}
+
+// AddUserGroupToGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) AddUserGroupToGroup(
+	ctx context.Context,
+	in *pb.Membership,
+) (*emptypb.Empty, error) {
+	return empty(
+		s.authModule.AddUserGroupToGroup(in.GetMember(), in.GetGroup()),
+	)
+}
+
+// GetUserMemberships implements AuthAdminServiceServer.
+func (s *AuthAdminServer) GetUserMemberships(
+	ctx context.Context,
+	in *pb.Member,
+) (*pb.NestedMembership, error) {
+	memberships, err := s.authModule.GetUserMemberships(in.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.NestedMembership{
+		Direct:    memberships.Direct,
+		Inherited: memberships.Inherited,
+	}, nil
+}
+
+// ListTimeBoundMemberships implements AuthAdminServiceServer.
+func (s *AuthAdminServer) ListTimeBoundMemberships(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.Memberships, error) {
+	memberships, err := s.authModule.ListTimeBoundMemberships()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := make([]*pb.Membership, 0, len(memberships))
+	for _, membership := range memberships {
+		result = append(result, &pb.Membership{
+			Group:     membership.Group,
+			Member:    membership.Member,
+			NotBefore: toTimestamp(membership.NotBefore),
+			ExpiresAt: toTimestamp(membership.ExpiresAt),
+		})
+	}
+
+	return &pb.Memberships{Memberships: result}, nil
+}
+
+// ListResourceGroups implements AuthAdminServiceServer.
+func (s *AuthAdminServer) ListResourceGroups(
+	ctx context.Context,
+	in *pb.ListRequest,
+) (*pb.GroupNames, error) {
+	page, err := s.authModule.ListResourceGroupsPage(
+		toListOptions(in.GetOptions()),
+	)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GroupNames{Names: page.Items, NextCursor: page.NextCursor}, nil
+}
+
+// CreateResourceGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) CreateResourceGroup(
+	ctx context.Context,
+	in *pb.GroupInfo,
+) (*emptypb.Empty, error) {
+	user := caller(ctx)
+	if in.GetName() == "" {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			"group name must not be empty",
+		)
+	}
+
+	info := toGroupInfo(in)
+	if user != auth.UnauthenticatedUser {
+		info.CreatedBy = user
+	}
+
+	return empty(s.authModule.CreateResourceGroupWithInfo(info))
+}
+
+// GetResourceGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) GetResourceGroup(
+	ctx context.Context,
+	in *pb.GroupQuery,
+) (*pb.Group, error) {
+	page, err := s.authModule.GetResourceGroupPage(
+		in.GetName(),
+		toListOptions(in.GetOptions()),
+	)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	info, err := s.authModule.GetResourceGroupInfo(in.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.Group{
+		Name:       in.GetName(),
+		Members:    page.Items,
+		NextCursor: page.NextCursor,
+		Info:       fromGroupInfo(info),
+	}, nil
+}
+
+// UpdateResourceGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) UpdateResourceGroup(
+	ctx context.Context,
+	in *pb.UpdateGroupRequest,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.Update(func(tx *auth.Tx) error {
+		info, err := tx.GetResourceGroupInfo(in.GetName())
+		if err != nil {
+			return err
+		}
+
+		return tx.UpdateResourceGroupInfo(updateInfo(info, in))
+	}))
+}
+
+// DeleteResourceGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) DeleteResourceGroup(
+	ctx context.Context,
+	in *pb.GroupName,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.RemoveResourceGroup(in.GetName()))
+}
+
+// AddResourceToGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) AddResourceToGroup(
+	ctx context.Context,
+	in *pb.Membership,
+) (*emptypb.Empty, error) {
+	return empty(
+		s.authModule.AddResourceToGroup(in.GetMember(), in.GetGroup()),
+	)
+}
+
+// RemoveResourceFromGroup implements AuthAdminServiceServer.
+func (s *AuthAdminServer) RemoveResourceFromGroup(
+	ctx context.Context,
+	in *pb.Membership,
+) (*emptypb.Empty, error) {
+	return empty(
+		s.authModule.RemoveResourceFromGroup(in.GetMember(), in.GetGroup()),
+	)
+}
+
+// RemoveResource implements AuthAdminServiceServer.
+func (s *AuthAdminServer) RemoveResource(
+	ctx context.Context,
+	in *pb.Member,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.RemoveResource(in.GetName()))
+}
+
+// GetGroupsForResource implements AuthAdminServiceServer.
+func (s *AuthAdminServer) GetGroupsForResource(
+	ctx context.Context,
+	in *pb.Member,
+) (*pb.GroupNames, error) {
+	groups, err := s.authModule.GetGroupsForResource(in.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GroupNames{Names: groups}, nil
+}
+
+// ListPolicies implements AuthAdminServiceServer.
+func (s *AuthAdminServer) ListPolicies(
+	ctx context.Context,
+	in *pb.ListRequest,
+) (*pb.Policies, error) {
+	page, err := s.authModule.ListPoliciesPage(toListOptions(in.GetOptions()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	result := make([]*pb.Policy, 0, len(page.Items))
+	for _, policy := range page.Items {
+		result = append(result, &pb.Policy{
+			UserGroup:     policy.UserGroup,
+			ResourceGroup: policy.ResourceGroup,
+			Permission:    policy.Permission,
+			Effect:        string(policy.Effect),
+			NotBefore:     toTimestamp(policy.NotBefore),
+			ExpiresAt:     toTimestamp(policy.ExpiresAt),
+		})
+	}
+
+	return &pb.Policies{Policies: result, NextCursor: page.NextCursor}, nil
+}
+
+// AddPolicy implements AuthAdminServiceServer.
+func (s *AuthAdminServer) AddPolicy(
+	ctx context.Context,
+	in *pb.Policy,
+) (*emptypb.Empty, error) {
+	add := s.authModule.AddPolicy
+	switch auth.Effect(in.GetEffect()) {
+	case "", auth.EffectAllow:
+	case auth.EffectDeny:
+		add = s.authModule.AddDenyPolicy
+	default:
+		return nil, invalidEffect(in.GetEffect())
+	}
+
+	validity, bounded := toValidity(in.GetNotBefore(), in.GetExpiresAt())
+	if bounded {
+		return empty(s.authModule.AddTimeBoundPolicy(auth.Policy{
+			UserGroup:     in.GetUserGroup(),
+			ResourceGroup: in.GetResourceGroup(),
+			Permission:    in.GetPermission(),
+			Effect:        auth.Effect(in.GetEffect()),
+		}, validity))
+	}
+
+	return empty(
+		add(in.GetUserGroup(), in.GetResourceGroup(), in.GetPermission()),
+	)
+}
+
+// RemovePolicy implements AuthAdminServiceServer.
+func (s *AuthAdminServer) RemovePolicy(
+	ctx context.Context,
+	in *pb.Policy,
+) (*emptypb.Empty, error) {
+	remove := s.authModule.RemovePolicy
+	switch auth.Effect(in.GetEffect()) {
+	case "", auth.EffectAllow:
+	case auth.EffectDeny:
+		remove = s.authModule.RemoveDenyPolicy
+	default:
+		return nil, invalidEffect(in.GetEffect())
+	}
+
+	return empty(
+		remove(in.GetUserGroup(), in.GetResourceGroup(), in.GetPermission()),
+	)
+}
+
+// ListTenants implements AuthAdminServiceServer.
+func (s *AuthAdminServer) ListTenants(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.GroupNames, error) {
+	tenants, err := s.authModule.ListTenants()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GroupNames{Names: tenants}, nil
+}
+
+// CreateTenant implements AuthAdminServiceServer.
+func (s *AuthAdminServer) CreateTenant(
+	ctx context.Context,
+	in *pb.GroupName,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.CreateTenant(in.GetName()))
+}
+
+// DeleteTenant implements AuthAdminServiceServer.
+func (s *AuthAdminServer) DeleteTenant(
+	ctx context.Context,
+	in *pb.GroupName,
+) (*emptypb.Empty, error) {
+	return empty(s.authModule.RemoveTenant(in.GetName()))
+}
+
+// UnaryInterceptor authorizes all calls of AuthAdminService methods, see
+// authorize, and passes calls of other services through.
+func (s *AuthAdminServer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if !strings.HasPrefix(info.FullMethod, authAdminMethodPrefix) {
+			return handler(ctx, req)
+		}
+
+		user, err := s.authorize(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, callerKey{}, user), req)
+	}
+}
+
+// caller returns the caller the interceptor authorized.
+func caller(ctx context.Context) string {
+	user, ok := ctx.Value(callerKey{}).(string)
+	if !ok {
+		return auth.UnauthenticatedUser
+	}
+
+	return user
+}
+
+// authorize authenticates the caller from the request metadata, checks that it
+// is a member of enclave_admin and returns it. Requests without credentials are
+// treated as the unauthenticated user, like in the REST middleware.
+func (s *AuthAdminServer) authorize(ctx context.Context) (string, error) {
+	user := auth.UnauthenticatedUser
+	if username, password, ok := basicAuth(ctx); ok {
+		userID, err := s.authenticator(ctx, username, password)
+		if err != nil {
+			log.Debug().Err(err).Msg("Basic authentication failed")
+
+			return "", status.Error(codes.Unauthenticated, "authentication failed")
+		}
+		user = userID
+	}
+
+	isAdmin, err := s.authModule.IsAdmin(user)
+	if err != nil {
+		return "", toStatus(err)
+	}
+	if !isAdmin {
+		log.Warn().
+			Str("user", user).
+			Msg("Denied access to auth administration")
+
+		return "", status.Errorf(
+			codes.PermissionDenied,
+			"%s is not allowed to administrate authorization",
+			user,
+		)
+	}
+
+	return user, nil
+}
+
+// basicAuth extracts BasicAuth credentials from the authorization metadata.
+func basicAuth(ctx context.Context) (string, string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", false
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", "", false
+	}
+
+	const prefix = "Basic "
+	if len(values[0]) < len(prefix) ||
+		!strings.EqualFold(values[0][:len(prefix)], prefix) {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(values[0][len(prefix):])
+	if err != nil {
+		return "", "", false
+	}
+
+	return strings.Cut(string(decoded), ":")
+}
+
+// toStatus maps errors of the auth module to gRPC status errors.
+// A ConflictError means the operation is not allowed in the current state
+// (e.g. removing the enclave_admin group), hence FailedPrecondition. Other
+// errors, like failures of the casbin adapter, are logged and not returned to
+// callers, they may contain details of the storage.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, &auth.NotFoundError{}):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, &auth.ConflictError{}):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, &auth.ForbiddenError{}):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, &auth.ValidationError{}):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		log.Error().Err(err).Msg("Auth administration failed")
+
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func invalidEffect(effect string) error {
+	return status.Errorf(
+		codes.InvalidArgument,
+		"effect must be allow or deny, got %q",
+		effect,
+	)
+}
+
+// toValidity returns the requested validity and whether it bounds anything.
+func toValidity(
+	notBefore, expiresAt *timestamppb.Timestamp,
+) (auth.Validity, bool) {
+	var validity auth.Validity
+	if notBefore != nil {
+		validity.NotBefore = notBefore.AsTime()
+	}
+	if expiresAt != nil {
+		validity.ExpiresAt = expiresAt.AsTime()
+	}
+
+	return validity, notBefore != nil || expiresAt != nil
+}
+
+// toTimestamp returns nil for the zero time, which leaves a Validity open.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+// toGroupInfo returns the description and owner of a group, the creation
+// time and creator are set by the AuthModule.
+func toGroupInfo(in *pb.GroupInfo) auth.GroupInfo {
+	return auth.GroupInfo{
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+		Owner:       in.GetOwner(),
+	}
+}
+
+// updateInfo returns info with the description and owner set in in.
+func updateInfo(info auth.GroupInfo, in *pb.UpdateGroupRequest) auth.GroupInfo {
+	if in.Description != nil {
+		info.Description = in.GetDescription()
+	}
+	if in.Owner != nil {
+		info.Owner = in.GetOwner()
+	}
+
+	return info
+}
+
+func fromGroupInfo(info auth.GroupInfo) *pb.GroupInfo {
+	return &pb.GroupInfo{
+		Name:        info.Name,
+		Description: info.Description,
+		Owner:       info.Owner,
+		CreatedAt:   toTimestamp(info.CreatedAt),
+		CreatedBy:   info.CreatedBy,
+	}
+}
+
+func empty(err error) (*emptypb.Empty, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// toListOptions returns the options of a listing, nil lists all entries.
+func toListOptions(opts *pb.ListOptions) auth.ListOptions {
+	return auth.ListOptions{
+		Prefix:   opts.GetPrefix(),
+		Contains: opts.GetContains(),
+		Limit:    int(opts.GetLimit()),
+		Cursor:   opts.GetCursor(),
+	}
+}
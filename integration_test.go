@@ -2,6 +2,9 @@ package shareddeps_test
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
 	"os"
 	"strconv"
 	"sync"
@@ -16,12 +19,19 @@ import (
 	fileadapter "github.com/casbin/casbin/v3/persist/file-adapter"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var serverInitMu sync.Mutex
 
-func startRESTServer(t *testing.T, port int) {
+func startRESTServer(t *testing.T, port int) auth.AuthModule {
+	t.Helper()
+
 	tmpDir := t.TempDir()
 
 	err := os.WriteFile(tmpDir+"/policies.csv", []byte(""), 0o644)
@@ -41,6 +51,11 @@ func startRESTServer(t *testing.T, port int) {
 	// Create a new config instance for this test
 	cfg := &config.BaseConfig{Port: port}
 	shareddeps.PopulateAppConfig(cfg, "test-REST-service", "v0.6.0", defaults...)
+	cfg.Auth = config.AuthConfig{
+		ResourceGroups: []config.GroupConfig{
+			{Name: "defaults", Members: []string{"/defaults/*"}},
+		},
+	}
 	server := shareddeps.InitRESTServer(cfg)
 	authModule := auth.NewModule(fileadapter.NewAdapter(tmpDir + "/policies.csv"))
 
@@ -53,23 +68,707 @@ func startRESTServer(t *testing.T, port int) {
 			},
 		},
 	)
+	shareddeps.ApplyAuthConfig(cfg, authModule)
+	shareddeps.RegisterAuthAdminRoutes(server, authModule)
 	go shareddeps.StartRESTServer(cfg, server)
 	time.Sleep(3 * time.Second)
+
+	return authModule
 }
 
 func TestRESTHealthCheck(t *testing.T) {
 	t.Parallel()
 	port := 8901
-	startRESTServer(t, port)
+	authModule := startRESTServer(t, port)
 	c, _ := client.NewClientWithResponses(
 		"http://localhost:" + strconv.Itoa(port),
 	)
 	resp, err := c.GetHealthWithResponse(t.Context())
 	assert.NoError(t, err)
 	assert.Equal(t, 200, resp.StatusCode())
+
+	// ApplyAuthConfig applies the auth section of the config
+	resources, err := authModule.GetResourceGroup("defaults")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/defaults/*"}, resources)
+}
+
+func TestRESTExplainDecision(t *testing.T) {
+	t.Parallel()
+	port := 8903
+	authModule := startRESTServer(t, port)
+	c, _ := client.NewClientWithResponses(
+		"http://localhost:" + strconv.Itoa(port),
+	)
+	allow := client.PolicyEffectAllow
+	params := &client.ExplainDecisionParams{
+		User:     "other-user",
+		Resource: "/health",
+		Action:   http.MethodGet,
+	}
+	withBasicAuth := func(ctx context.Context, req *http.Request) error {
+		req.SetBasicAuth("test-user", "password")
+
+		return nil
+	}
+
+	// Unauthenticated and non-admin callers are rejected
+	resp, err := c.ExplainDecisionWithResponse(t.Context(), params)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode())
+
+	resp, err = c.ExplainDecisionWithResponse(t.Context(), params, withBasicAuth)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode())
+
+	err = authModule.AddUserToGroup("test-user-id", "enclave_admin")
+	assert.NoError(t, err)
+
+	resp, err = c.ExplainDecisionWithResponse(t.Context(), params, withBasicAuth)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	if assert.NotNil(t, resp.JSON200) {
+		assert.True(t, resp.JSON200.Allowed)
+		assert.Equal(t, []string{"health_INTERNAL"}, resp.JSON200.ResourceGroups)
+		assert.Equal(t, &client.Policy{
+			UserGroup:     "*",
+			ResourceGroup: "health_INTERNAL",
+			Permission:    http.MethodGet,
+			Effect:        &allow,
+		}, resp.JSON200.MatchedPolicy)
+	}
+}
+
+func TestRESTEffectivePermissions(t *testing.T) {
+	t.Parallel()
+	port := 8904
+	authModule := startRESTServer(t, port)
+	c, _ := client.NewClientWithResponses(
+		"http://localhost:" + strconv.Itoa(port),
+	)
+	allow := client.PolicyEffectAllow
+	withBasicAuth := func(ctx context.Context, req *http.Request) error {
+		req.SetBasicAuth("test-user", "password")
+
+		return nil
+	}
+
+	err := authModule.AddUserToGroup("test-user-id", "enclave_admin")
+	assert.NoError(t, err)
+
+	resp, err := c.GetEffectivePermissionsWithResponse(
+		t.Context(),
+		"other-user",
+		nil,
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	if assert.NotNil(t, resp.JSON200) {
+		assert.Equal(t, "other-user", resp.JSON200.User)
+		assert.Equal(t, []client.EffectivePermission{
+			{
+				Resource: "/health",
+				Method:   http.MethodGet,
+				Policy: client.Policy{
+					UserGroup:     "*",
+					ResourceGroup: "health_INTERNAL",
+					Permission:    http.MethodGet,
+					Effect:        &allow,
+				},
+			},
+		}, resp.JSON200.Permissions)
+	}
+
+	format := client.ReportFormatCSV
+	resp, err = c.GetEffectivePermissionsWithResponse(
+		t.Context(),
+		"other-user",
+		&client.GetEffectivePermissionsParams{Format: &format},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, "text/csv", resp.HTTPResponse.Header.Get("Content-Type"))
+	assert.Equal(
+		t,
+		"user,resource,method,userGroup,resourceGroup,permission,effect\n"+
+			"other-user,/health,GET,*,health_INTERNAL,GET,allow\n",
+		string(resp.Body),
+	)
+}
+
+func TestRESTWhoCanAccess(t *testing.T) {
+	t.Parallel()
+	port := 8905
+	authModule := startRESTServer(t, port)
+	c, _ := client.NewClientWithResponses(
+		"http://localhost:" + strconv.Itoa(port),
+	)
+	withBasicAuth := func(ctx context.Context, req *http.Request) error {
+		req.SetBasicAuth("test-user", "password")
+
+		return nil
+	}
+
+	err := authModule.AddUserToGroup("test-user-id", "enclave_admin")
+	assert.NoError(t, err)
+
+	resp, err := c.WhoCanAccessWithResponse(
+		t.Context(),
+		&client.WhoCanAccessParams{Resource: "/health", Method: http.MethodGet},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	if assert.NotNil(t, resp.JSON200) {
+		assert.True(t, resp.JSON200.Everyone)
+		assert.Equal(t, []string{"enclave_admin"}, resp.JSON200.UserGroups)
+		assert.Equal(t, []string{"test-user-id"}, resp.JSON200.Users)
+	}
+}
+
+func TestRESTAuthAdministration(t *testing.T) {
+	t.Parallel()
+	port := 8906
+	authModule := startRESTServer(t, port)
+	c, _ := client.NewClientWithResponses(
+		"http://localhost:" + strconv.Itoa(port),
+	)
+	allow := client.PolicyEffectAllow
+	withBasicAuth := func(ctx context.Context, req *http.Request) error {
+		req.SetBasicAuth("test-user", "password")
+
+		return nil
+	}
+
+	// Only enclave admins may use the administration endpoints
+	listResp, err := c.ListUserGroupsWithResponse(t.Context(), nil, withBasicAuth)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, listResp.StatusCode())
+
+	err = authModule.AddUserToGroup("test-user-id", "enclave_admin")
+	assert.NoError(t, err)
+
+	// User groups
+	description := "CI runners"
+	createResp, err := c.CreateUserGroupWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "runners", Description: &description},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, createResp.StatusCode())
+
+	createResp, err = c.CreateUserGroupWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "run*"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, createResp.StatusCode())
+
+	createResp, err = c.CreateUserGroupWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "null_user"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, createResp.StatusCode())
+
+	addUserResp, err := c.AddUserToGroupWithResponse(
+		t.Context(),
+		"runners",
+		client.AddUserRequest{User: "runner-1"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, addUserResp.StatusCode())
+
+	addUserResp, err = c.AddUserToGroupWithResponse(
+		t.Context(),
+		"unknown",
+		client.AddUserRequest{User: "runner-1"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, addUserResp.StatusCode())
+	assert.NotNil(t, addUserResp.JSON404)
+
+	expiresAt := time.Now().Add(time.Hour).UTC()
+	addUserResp, err = c.AddUserToGroupWithResponse(
+		t.Context(),
+		"runners",
+		client.AddUserRequest{User: "contractor", ExpiresAt: &expiresAt},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, addUserResp.StatusCode())
+
+	expired := time.Now().Add(-time.Hour)
+	addUserResp, err = c.AddUserToGroupWithResponse(
+		t.Context(),
+		"runners",
+		client.AddUserRequest{User: "contractor", ExpiresAt: &expired},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, addUserResp.StatusCode())
+
+	timeBoundResp, err := c.ListTimeBoundMembershipsWithResponse(
+		t.Context(),
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	if assert.NotNil(t, timeBoundResp.JSON200) &&
+		assert.Len(t, *timeBoundResp.JSON200, 1) {
+		membership := (*timeBoundResp.JSON200)[0]
+		assert.Equal(t, "contractor", membership.Member)
+		assert.Nil(t, membership.NotBefore)
+		if assert.NotNil(t, membership.ExpiresAt) {
+			assert.True(t, expiresAt.Equal(*membership.ExpiresAt))
+		}
+	}
+
+	removeContractorResp, err := c.RemoveUserFromGroupWithResponse(
+		t.Context(),
+		"runners",
+		"contractor",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, removeContractorResp.StatusCode())
+
+	listResp, err = c.ListUserGroupsWithResponse(t.Context(), nil, withBasicAuth)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, listResp.StatusCode())
+	assert.Equal(t, []string{"enclave_admin", "runners"}, *listResp.JSON200)
+
+	// Listings are paginated with the cursor of the X-Next-Cursor header
+	limit := 1
+	listResp, err = c.ListUserGroupsWithResponse(
+		t.Context(),
+		&client.ListUserGroupsParams{Limit: &limit},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"enclave_admin"}, *listResp.JSON200)
+	cursor := listResp.HTTPResponse.Header.Get("X-Next-Cursor")
+	assert.NotEmpty(t, cursor)
+	listResp, err = c.ListUserGroupsWithResponse(
+		t.Context(),
+		&client.ListUserGroupsParams{Limit: &limit, Cursor: &cursor},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"runners"}, *listResp.JSON200)
+	assert.Empty(t, listResp.HTTPResponse.Header.Get("X-Next-Cursor"))
+
+	invalidCursor := "not a cursor"
+	listResp, err = c.ListUserGroupsWithResponse(
+		t.Context(),
+		&client.ListUserGroupsParams{Cursor: &invalidCursor},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, listResp.StatusCode())
+
+	groupResp, err := c.GetUserGroupWithResponse(
+		t.Context(),
+		"runners",
+		nil,
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, groupResp.StatusCode())
+	if assert.NotNil(t, groupResp.JSON200) {
+		group := groupResp.JSON200
+		assert.Equal(t, "runners", group.Name)
+		assert.Equal(t, []string{"runner-1"}, group.Members)
+		assert.Equal(t, &[]string{}, group.InheritedMembers)
+		assert.Equal(t, &description, group.Description)
+		assert.Nil(t, group.Owner)
+		assert.Equal(t, "test-user-id", *group.CreatedBy)
+		assert.NotNil(t, group.CreatedAt)
+	}
+
+	owner := "runner-1"
+	updateResp, err := c.UpdateUserGroupWithResponse(
+		t.Context(),
+		"runners",
+		client.UpdateGroupRequest{Owner: &owner},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, updateResp.StatusCode())
+	groupResp, err = c.GetUserGroupWithResponse(
+		t.Context(),
+		"runners",
+		nil,
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	if assert.NotNil(t, groupResp.JSON200) {
+		assert.Equal(t, &owner, groupResp.JSON200.Owner)
+		assert.Equal(t, &description, groupResp.JSON200.Description)
+	}
+
+	updateResp, err = c.UpdateUserGroupWithResponse(
+		t.Context(),
+		"unknown",
+		client.UpdateGroupRequest{Owner: &owner},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, updateResp.StatusCode())
+
+	userGroupsResp, err := c.GetGroupsForUserWithResponse(
+		t.Context(),
+		"runner-1",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"runners"}, *userGroupsResp.JSON200)
+
+	// Nested user groups
+	assert.NoError(t, authModule.CreateUserGroup("runner-admins"))
+	assert.NoError(t, authModule.AddUserToGroup("admin-1", "runner-admins"))
+	nestResp, err := c.AddUserGroupToGroupWithResponse(
+		t.Context(),
+		"runners",
+		client.AddGroupRequest{Group: "runner-admins"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, nestResp.StatusCode())
+
+	nestResp, err = c.AddUserGroupToGroupWithResponse(
+		t.Context(),
+		"runner-admins",
+		client.AddGroupRequest{Group: "runners"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, nestResp.StatusCode())
+	assert.NotNil(t, nestResp.JSON409)
+
+	membershipsResp, err := c.GetUserMembershipsWithResponse(
+		t.Context(),
+		"admin-1",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		&client.NestedMembership{
+			Direct:    []string{"runner-admins"},
+			Inherited: []string{"runners"},
+		},
+		membershipsResp.JSON200,
+	)
+
+	groupResp, err = c.GetUserGroupWithResponse(
+		t.Context(),
+		"runners",
+		nil,
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	if assert.NotNil(t, groupResp.JSON200) {
+		assert.Equal(
+			t,
+			[]string{"runner-1", "runner-admins"},
+			groupResp.JSON200.Members,
+		)
+		assert.Equal(t, &[]string{"admin-1"}, groupResp.JSON200.InheritedMembers)
+	}
+
+	// Resource groups
+	createResp2, err := c.CreateResourceGroupWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "runs"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, createResp2.StatusCode())
+
+	createResp2, err = c.CreateResourceGroupWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "/v1/runs/:id"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, createResp2.StatusCode())
+
+	createResp2, err = c.CreateResourceGroupWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "null_resource"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, createResp2.StatusCode())
+
+	addResourceResp, err := c.AddResourceToGroupWithResponse(
+		t.Context(),
+		"runs",
+		client.AddResourceRequest{Resource: "/v1/runs/:id"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, addResourceResp.StatusCode())
+
+	addResourceResp, err = c.AddResourceToGroupWithResponse(
+		t.Context(),
+		"runs",
+		client.AddResourceRequest{Resource: "/v1/runs/:id/"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, addResourceResp.StatusCode())
+
+	resourceGroupsResp, err := c.GetGroupsForResourceWithResponse(
+		t.Context(),
+		&client.GetGroupsForResourceParams{Resource: "/v1/runs/:id"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"runs"}, *resourceGroupsResp.JSON200)
+
+	// Policies
+	addPolicyResp, err := c.AddPolicyWithResponse(
+		t.Context(),
+		client.Policy{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    http.MethodGet,
+		},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, addPolicyResp.StatusCode())
+
+	policiesResp, err := c.ListPoliciesWithResponse(
+		t.Context(),
+		nil,
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Contains(t, *policiesResp.JSON200, client.Policy{
+		UserGroup:     "runners",
+		ResourceGroup: "runs",
+		Permission:    http.MethodGet,
+		Effect:        &allow,
+	})
+
+	allowed, err := authModule.Check(
+		auth.SetAuthenticatedUser(t.Context(), "runner-1"),
+		"/v1/runs/1",
+		http.MethodGet,
+	)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	deny := client.PolicyEffectDeny
+	addPolicyResp, err = c.AddPolicyWithResponse(
+		t.Context(),
+		client.Policy{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    http.MethodGet,
+			Effect:        &deny,
+		},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, addPolicyResp.StatusCode())
+
+	allowed, err = authModule.Check(
+		auth.SetAuthenticatedUser(t.Context(), "runner-1"),
+		"/v1/runs/1",
+		http.MethodGet,
+	)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	invalid := client.PolicyEffect("maybe")
+	addPolicyResp, err = c.AddPolicyWithResponse(
+		t.Context(),
+		client.Policy{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    http.MethodGet,
+			Effect:        &invalid,
+		},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, addPolicyResp.StatusCode())
+
+	removePolicyResp, err := c.RemovePolicyWithResponse(
+		t.Context(),
+		&client.RemovePolicyParams{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    http.MethodGet,
+			Effect:        &deny,
+		},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, removePolicyResp.StatusCode())
+
+	removePolicyResp, err = c.RemovePolicyWithResponse(
+		t.Context(),
+		&client.RemovePolicyParams{
+			UserGroup:     "enclave_admin",
+			ResourceGroup: "*",
+			Permission:    "*",
+		},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, removePolicyResp.StatusCode())
+
+	maybe := client.PolicyEffect("maybe")
+	removePolicyResp, err = c.RemovePolicyWithResponse(
+		t.Context(),
+		&client.RemovePolicyParams{
+			UserGroup:     "runners",
+			ResourceGroup: "runs",
+			Permission:    http.MethodGet,
+			Effect:        &maybe,
+		},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, removePolicyResp.StatusCode())
+
+	// Cleanup
+	removeResourceResp, err := c.RemoveResourceFromGroupWithResponse(
+		t.Context(),
+		"runs",
+		&client.RemoveResourceFromGroupParams{Resource: "/v1/runs/:id"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, removeResourceResp.StatusCode())
+
+	removeUserResp, err := c.RemoveUserFromGroupWithResponse(
+		t.Context(),
+		"runners",
+		"runner-1",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, removeUserResp.StatusCode())
+
+	deleteResp, err := c.DeleteUserGroupWithResponse(
+		t.Context(),
+		"runners",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, deleteResp.StatusCode())
+
+	deleteResp, err = c.DeleteUserGroupWithResponse(
+		t.Context(),
+		"enclave_admin",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, deleteResp.StatusCode())
+
+	// Tenant scoped groups need their tenant
+	createResp, err = c.CreateUserGroupWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "acme::developers"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, createResp.StatusCode())
+
+	createTenantResp, err := c.CreateTenantWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "acme"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, createTenantResp.StatusCode())
+
+	createResp, err = c.CreateUserGroupWithResponse(
+		t.Context(),
+		client.CreateGroupRequest{Name: "acme::developers"},
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, createResp.StatusCode())
+
+	tenantsResp, err := c.ListTenantsWithResponse(t.Context(), withBasicAuth)
+	assert.NoError(t, err)
+	if assert.NotNil(t, tenantsResp.JSON200) {
+		assert.Equal(t, []string{"acme"}, *tenantsResp.JSON200)
+	}
+
+	deleteTenantResp, err := c.DeleteTenantWithResponse(
+		t.Context(),
+		"acme",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, deleteTenantResp.StatusCode())
+
+	deleteTenantResp, err = c.DeleteTenantWithResponse(
+		t.Context(),
+		"acme",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, deleteTenantResp.StatusCode())
+
+	deleteResourceGroupResp, err := c.DeleteResourceGroupWithResponse(
+		t.Context(),
+		"runs",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, deleteResourceGroupResp.StatusCode())
+
+	// Health checks are protected
+	deleteResourceGroupResp, err = c.DeleteResourceGroupWithResponse(
+		t.Context(),
+		"health_INTERNAL",
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, deleteResourceGroupResp.StatusCode())
+	if assert.NotNil(t, deleteResourceGroupResp.JSON409) {
+		assert.Contains(
+			t,
+			deleteResourceGroupResp.JSON409.Message,
+			"health checks",
+		)
+	}
+
+	policiesResp, err = c.ListPoliciesWithResponse(
+		t.Context(),
+		nil,
+		withBasicAuth,
+	)
+	assert.NoError(t, err)
+	assert.NotContains(t, *policiesResp.JSON200, client.Policy{
+		UserGroup:     "runners",
+		ResourceGroup: "runs",
+		Permission:    http.MethodGet,
+		Effect:        &allow,
+	})
 }
 
-func startGRPCServer(t *testing.T, port int) {
+func startGRPCServer(
+	t *testing.T,
+	port int,
+	register func(*grpc.Server),
+	options ...shareddeps.GRPCOption,
+) {
 	tmpDir := t.TempDir()
 	err := os.WriteFile(tmpDir+"/policies.csv", []byte(""), 0o644)
 	assert.NoError(t, err)
@@ -87,12 +786,9 @@ func startGRPCServer(t *testing.T, port int) {
 	// Create a new config instance for this test
 	cfg := &config.BaseConfig{Port: port}
 	shareddeps.PopulateAppConfig(cfg, "test-GRPC-service", "v0.6.0", defaults...)
-	server := shareddeps.InitGRPCServer()
+	server := shareddeps.InitGRPCServer(options...)
 
-	pb.RegisterHealthServiceServer(
-		server,
-		&healthServiceServer{},
-	)
+	register(server)
 	go shareddeps.StartGRPCServer(cfg, server)
 	time.Sleep(3 * time.Second)
 }
@@ -112,7 +808,9 @@ func (s *healthServiceServer) CheckHealth(
 func TestGRPCHealthCheck(t *testing.T) {
 	t.Parallel()
 	port := 8902
-	startGRPCServer(t, port)
+	startGRPCServer(t, port, func(server *grpc.Server) {
+		pb.RegisterHealthServiceServer(server, &healthServiceServer{})
+	})
 
 	conn, err := grpc.NewClient(
 		"localhost:"+strconv.Itoa(port),
@@ -132,3 +830,244 @@ func TestGRPCHealthCheck(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "SERVING", resp.Status)
 }
+
+func TestGRPCAuthAdministration(t *testing.T) {
+	t.Parallel()
+	port := 8907
+
+	policyFile := t.TempDir() + "/policies.csv"
+	err := os.WriteFile(policyFile, []byte(""), 0o644)
+	assert.NoError(t, err)
+	authModule := auth.NewModule(fileadapter.NewAdapter(policyFile))
+	startGRPCServer(
+		t,
+		port,
+		func(server *grpc.Server) {
+			pb.RegisterHealthServiceServer(server, &healthServiceServer{})
+		},
+		shareddeps.WithAuthAdminService(
+			authModule,
+			shareddeps.Authentication{
+				BasicAuthenticator: func(ctx context.Context, username, password string) (string, error) {
+					if password != "secret" {
+						return "", errors.New("invalid password")
+					}
+
+					return username, nil
+				},
+			},
+		),
+	)
+	err = authModule.AddUserToGroup("admin", "enclave_admin")
+	assert.NoError(t, err)
+
+	conn, err := grpc.NewClient(
+		"localhost:"+strconv.Itoa(port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	defer func() {
+		err := conn.Close()
+		assert.NoError(t, err)
+	}()
+
+	adminClient := pb.NewAuthAdminServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	withUser := func(user, password string) context.Context {
+		credentials := base64.StdEncoding.EncodeToString(
+			[]byte(user + ":" + password),
+		)
+
+		return metadata.AppendToOutgoingContext(
+			ctx,
+			"authorization",
+			"Basic "+credentials,
+		)
+	}
+
+	// Anonymous, unauthenticated and non-admin callers are rejected
+	_, err = adminClient.ListUserGroups(ctx, &pb.ListRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = adminClient.ListUserGroups(
+		withUser("admin", "wrong"),
+		&pb.ListRequest{},
+	)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = adminClient.ListUserGroups(
+		withUser("alice", "secret"),
+		&pb.ListRequest{},
+	)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Other services are not affected by the interceptor of the admin service
+	health, err := pb.NewHealthServiceClient(conn).
+		CheckHealth(ctx, &pb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "SERVING", health.GetStatus())
+
+	adminCtx := withUser("admin", "secret")
+
+	_, err = adminClient.CreateUserGroup(
+		adminCtx,
+		&pb.GroupInfo{Name: "runners", Description: "CI runners"},
+	)
+	assert.NoError(t, err)
+	_, err = adminClient.AddUserToGroup(
+		adminCtx,
+		&pb.Membership{Group: "runners", Member: "alice"},
+	)
+	assert.NoError(t, err)
+	_, err = adminClient.AddUserToGroup(
+		adminCtx,
+		&pb.Membership{Group: "missing", Member: "alice"},
+	)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	groups, err := adminClient.ListUserGroups(adminCtx, &pb.ListRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"enclave_admin", "runners"}, groups.GetNames())
+
+	group, err := adminClient.GetUserGroup(
+		adminCtx,
+		&pb.GroupQuery{Name: "runners"},
+	)
+	assert.NoError(t, err)
+	assert.Contains(t, group.GetMembers(), "alice")
+	assert.Equal(t, "CI runners", group.GetInfo().GetDescription())
+	assert.Equal(t, "admin", group.GetInfo().GetCreatedBy())
+	assert.NotNil(t, group.GetInfo().GetCreatedAt())
+
+	// Fields not set in the request are kept
+	_, err = adminClient.UpdateUserGroup(
+		adminCtx,
+		&pb.UpdateGroupRequest{Name: "runners", Owner: proto.String("alice")},
+	)
+	assert.NoError(t, err)
+	group, err = adminClient.GetUserGroup(
+		adminCtx,
+		&pb.GroupQuery{Name: "runners"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", group.GetInfo().GetOwner())
+	assert.Equal(t, "CI runners", group.GetInfo().GetDescription())
+	assert.Equal(t, "admin", group.GetInfo().GetCreatedBy())
+
+	_, err = adminClient.UpdateUserGroup(
+		adminCtx,
+		&pb.UpdateGroupRequest{Name: "runners", Description: proto.String("")},
+	)
+	assert.NoError(t, err)
+	group, err = adminClient.GetUserGroup(
+		adminCtx,
+		&pb.GroupQuery{Name: "runners"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", group.GetInfo().GetOwner())
+	assert.Empty(t, group.GetInfo().GetDescription())
+
+	_, err = adminClient.UpdateResourceGroup(
+		adminCtx,
+		&pb.UpdateGroupRequest{Name: "missing", Owner: proto.String("alice")},
+	)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = adminClient.CreateUserGroup(
+		adminCtx,
+		&pb.GroupInfo{Name: "leads"},
+	)
+	assert.NoError(t, err)
+	_, err = adminClient.AddUserGroupToGroup(
+		adminCtx,
+		&pb.Membership{Group: "leads", Member: "runners"},
+	)
+	assert.NoError(t, err)
+	_, err = adminClient.AddUserGroupToGroup(
+		adminCtx,
+		&pb.Membership{Group: "runners", Member: "leads"},
+	)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	memberships, err := adminClient.GetUserMemberships(
+		adminCtx,
+		&pb.Member{Name: "alice"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"runners"}, memberships.GetDirect())
+	assert.Equal(t, []string{"leads"}, memberships.GetInherited())
+
+	_, err = adminClient.CreateResourceGroup(
+		adminCtx,
+		&pb.GroupInfo{Name: "runs"},
+	)
+	assert.NoError(t, err)
+	_, err = adminClient.AddResourceToGroup(
+		adminCtx,
+		&pb.Membership{Group: "runs", Member: "/runs/*"},
+	)
+	assert.NoError(t, err)
+
+	policy := &pb.Policy{
+		UserGroup:     "runners",
+		ResourceGroup: "runs",
+		Permission:    http.MethodGet,
+		Effect:        string(auth.EffectAllow),
+	}
+	_, err = adminClient.AddPolicy(adminCtx, policy)
+	assert.NoError(t, err)
+
+	policies, err := adminClient.ListPolicies(adminCtx, &pb.ListRequest{})
+	assert.NoError(t, err)
+	assert.Condition(t, func() bool {
+		for _, p := range policies.GetPolicies() {
+			if proto.Equal(p, policy) {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	expiring := &pb.Policy{
+		UserGroup:     "runners",
+		ResourceGroup: "runs",
+		Permission:    http.MethodPost,
+		Effect:        string(auth.EffectAllow),
+		ExpiresAt:     timestamppb.New(time.Now().Add(time.Hour)),
+	}
+	_, err = adminClient.AddPolicy(adminCtx, expiring)
+	assert.NoError(t, err)
+	policies, err = adminClient.ListPolicies(adminCtx, &pb.ListRequest{})
+	assert.NoError(t, err)
+	assert.Condition(t, func() bool {
+		for _, p := range policies.GetPolicies() {
+			if proto.Equal(p, expiring) {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	// The admin group is protected
+	_, err = adminClient.DeleteUserGroup(
+		adminCtx,
+		&pb.GroupName{Name: "enclave_admin"},
+	)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = adminClient.CreateUserGroup(adminCtx, &pb.GroupInfo{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Non-admin access is granted once alice becomes an admin
+	_, err = adminClient.AddUserToGroup(
+		adminCtx,
+		&pb.Membership{Group: "enclave_admin", Member: "alice"},
+	)
+	assert.NoError(t, err)
+	_, err = adminClient.ListUserGroups(
+		withUser("alice", "secret"),
+		&pb.ListRequest{},
+	)
+	assert.NoError(t, err)
+}
@@ -8,13 +8,23 @@ import (
 	"github.com/EnclaveRunner/shareddeps/api"
 	"github.com/EnclaveRunner/shareddeps/auth"
 	"github.com/EnclaveRunner/shareddeps/config"
+	"github.com/EnclaveRunner/shareddeps/grpcapi"
 	"github.com/EnclaveRunner/shareddeps/middleware"
+	"github.com/EnclaveRunner/shareddeps/proto_gen"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// healthDocument makes the health endpoint public.
+var healthDocument = auth.PolicyDocument{
+	ResourceGroups: map[string][]string{"health_INTERNAL": {"/health"}},
+	Policies: []auth.Policy{
+		{UserGroup: "*", ResourceGroup: "health_INTERNAL", Permission: "GET"},
+	},
+}
+
 type Authentication struct {
 	BasicAuthenticator middleware.BasicAuthenticator
 }
@@ -45,16 +55,58 @@ func InitRESTServer(cfg config.HasBaseConfig) *gin.Engine {
 
 	server := api.NewServer()
 	handler := api.NewStrictHandler(server, nil)
-	api.RegisterHandlers(restServer, handler)
+	api.RegisterPublicHandlers(restServer, handler)
 
 	log.Info().Msg("Server initialized with middleware")
 
 	return restServer
 }
 
-func InitGRPCServer() *grpc.Server {
+// GRPCOption configures the gRPC-Server created by InitGRPCServer.
+type GRPCOption func(*grpcSetup)
+
+type grpcSetup struct {
+	interceptors []grpc.UnaryServerInterceptor
+	services     []func(server *grpc.Server)
+}
+
+// WithAuthAdminService registers the AuthAdminService on the gRPC-Server.
+// Callers authenticate with BasicAuth credentials in the authorization
+// metadata and must be members of enclave_admin, which an interceptor checks
+// for all methods of the service.
+func WithAuthAdminService(
+	authModule auth.AuthModule,
+	authentication Authentication,
+) GRPCOption {
+	return func(setup *grpcSetup) {
+		adminServer := grpcapi.NewAuthAdminServer(
+			&authModule,
+			authentication.BasicAuthenticator,
+		)
+		setup.interceptors = append(
+			setup.interceptors,
+			adminServer.UnaryInterceptor(),
+		)
+		setup.services = append(setup.services, func(server *grpc.Server) {
+			proto_gen.RegisterAuthAdminServiceServer(server, adminServer)
+			log.Info().Msg("Auth administration service registered")
+		})
+	}
+}
+
+func InitGRPCServer(options ...GRPCOption) *grpc.Server {
+	setup := &grpcSetup{}
+	for _, option := range options {
+		option(setup)
+	}
+
 	// create the gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(setup.interceptors...),
+	)
+	for _, register := range setup.services {
+		register(grpcServer)
+	}
 
 	log.Info().Msg("gRPC server initialized")
 
@@ -113,6 +165,18 @@ func InitGRPCClient(host string, port int) *grpc.ClientConn {
 }
 
 // AddAuth adds authentication and authorization middleware to the REST-Server.
+// The auth administration endpoints are not mounted, see
+// ApplyAuthConfig applies the auth section of cfg to authModule, see
+// auth.AuthModule.ApplyDefaults. Alternatively pass auth.WithDefaults to
+// auth.NewModule.
+func ApplyAuthConfig(cfg config.HasBaseConfig, authModule auth.AuthModule) {
+	err := authModule.ApplyDefaults(cfg.GetBase().Auth)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to apply default auth config")
+	}
+}
+
+// RegisterAuthAdminRoutes.
 // Must be called after InitRESTServer and before StartRESTServer.
 func AddAuth(
 	server *gin.Engine,
@@ -122,23 +186,24 @@ func AddAuth(
 	server.Use(middleware.Authentication(authentication.BasicAuthenticator))
 	server.Use(authModule.Middleware())
 
-	// Add policy to allow health checks without authentication
-	err := authModule.CreateResourceGroup("health_INTERNAL")
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create health_INTERNAL resource group")
-	}
-	err = authModule.AddResourceToGroup("/health", "health_INTERNAL")
+	// Allow health checks without authentication, admins cannot revoke it
+	err := authModule.Protect("health checks", healthDocument)
 	if err != nil {
-		log.Fatal().
-			Err(err).
-			Msg("Failed to add /health to health_INTERNAL resource group")
-	}
-	err = authModule.AddPolicy("*", "health_INTERNAL", "GET")
-	if err != nil {
-		log.Fatal().
-			Err(err).
-			Msg("Failed to add policy for health_INTERNAL resource group")
+		log.Fatal().Err(err).Msg("Failed to add health_INTERNAL policy")
 	}
 
 	log.Info().Msg("Authentication and Authorization middleware added")
 }
+
+// RegisterAuthAdminRoutes mounts the auth administration endpoints under
+// /auth/ on the REST-Server. They are restricted to members of enclave_admin.
+// Must be called after AddAuth and before StartRESTServer.
+func RegisterAuthAdminRoutes(server *gin.Engine, authModule auth.AuthModule) {
+	adminHandler := api.NewStrictHandler(api.NewAuthServer(&authModule), nil)
+	api.RegisterAuthHandlers(
+		server.Group("", authModule.AdminMiddleware()),
+		adminHandler,
+	)
+
+	log.Info().Msg("Auth administration routes registered")
+}
@@ -0,0 +1,1074 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v6.33.0
+// source: auth-admin.proto
+
+package proto_gen
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AuthAdminServiceClient is the client API for AuthAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthAdminServiceClient interface {
+	// List rpcs return entries in ascending order, see ListOptions.
+	ListUserGroups(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*GroupNames, error)
+	// Create rpcs take the description and owner of the group, the creation
+	// time and creator are set by the service.
+	CreateUserGroup(ctx context.Context, in *GroupInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetUserGroup pages through the direct members, inherited members are
+	// always listed completely.
+	GetUserGroup(ctx context.Context, in *GroupQuery, opts ...grpc.CallOption) (*Group, error)
+	// Update rpcs change the description and owner of the group that are set
+	// in the request and keep the others.
+	UpdateUserGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteUserGroup(ctx context.Context, in *GroupName, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// AddUserToGroup adds a time-bound membership if not_before or expires_at
+	// is set.
+	AddUserToGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveUserFromGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveUser(ctx context.Context, in *Member, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetGroupsForUser(ctx context.Context, in *Member, opts ...grpc.CallOption) (*GroupNames, error)
+	// AddUserGroupToGroup nests the member group, rejecting cycles.
+	AddUserGroupToGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetUserMemberships(ctx context.Context, in *Member, opts ...grpc.CallOption) (*NestedMembership, error)
+	// ListTimeBoundMemberships includes memberships not in effect yet.
+	ListTimeBoundMemberships(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Memberships, error)
+	ListResourceGroups(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*GroupNames, error)
+	CreateResourceGroup(ctx context.Context, in *GroupInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetResourceGroup(ctx context.Context, in *GroupQuery, opts ...grpc.CallOption) (*Group, error)
+	UpdateResourceGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteResourceGroup(ctx context.Context, in *GroupName, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddResourceToGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveResourceFromGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveResource(ctx context.Context, in *Member, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetGroupsForResource(ctx context.Context, in *Member, opts ...grpc.CallOption) (*GroupNames, error)
+	// ListPolicies includes time-bound policies not in effect yet. Filters
+	// match the user group or the resource group.
+	ListPolicies(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*Policies, error)
+	// AddPolicy adds a time-bound policy if not_before or expires_at is set.
+	AddPolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemovePolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListTenants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GroupNames, error)
+	// CreateTenant creates the tenant and its admin group "<tenant>::admin".
+	CreateTenant(ctx context.Context, in *GroupName, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// DeleteTenant removes the tenant with all of its groups and policies.
+	DeleteTenant(ctx context.Context, in *GroupName, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type authAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthAdminServiceClient(cc grpc.ClientConnInterface) AuthAdminServiceClient {
+	return &authAdminServiceClient{cc}
+}
+
+func (c *authAdminServiceClient) ListUserGroups(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*GroupNames, error) {
+	out := new(GroupNames)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/ListUserGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) CreateUserGroup(ctx context.Context, in *GroupInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/CreateUserGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) GetUserGroup(ctx context.Context, in *GroupQuery, opts ...grpc.CallOption) (*Group, error) {
+	out := new(Group)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/GetUserGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) UpdateUserGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/UpdateUserGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) DeleteUserGroup(ctx context.Context, in *GroupName, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/DeleteUserGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) AddUserToGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/AddUserToGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) RemoveUserFromGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/RemoveUserFromGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) RemoveUser(ctx context.Context, in *Member, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/RemoveUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) GetGroupsForUser(ctx context.Context, in *Member, opts ...grpc.CallOption) (*GroupNames, error) {
+	out := new(GroupNames)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/GetGroupsForUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) AddUserGroupToGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/AddUserGroupToGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) GetUserMemberships(ctx context.Context, in *Member, opts ...grpc.CallOption) (*NestedMembership, error) {
+	out := new(NestedMembership)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/GetUserMemberships", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) ListTimeBoundMemberships(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Memberships, error) {
+	out := new(Memberships)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/ListTimeBoundMemberships", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) ListResourceGroups(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*GroupNames, error) {
+	out := new(GroupNames)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/ListResourceGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) CreateResourceGroup(ctx context.Context, in *GroupInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/CreateResourceGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) GetResourceGroup(ctx context.Context, in *GroupQuery, opts ...grpc.CallOption) (*Group, error) {
+	out := new(Group)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/GetResourceGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) UpdateResourceGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/UpdateResourceGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) DeleteResourceGroup(ctx context.Context, in *GroupName, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/DeleteResourceGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) AddResourceToGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/AddResourceToGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) RemoveResourceFromGroup(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/RemoveResourceFromGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) RemoveResource(ctx context.Context, in *Member, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/RemoveResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) GetGroupsForResource(ctx context.Context, in *Member, opts ...grpc.CallOption) (*GroupNames, error) {
+	out := new(GroupNames)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/GetGroupsForResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) ListPolicies(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*Policies, error) {
+	out := new(Policies)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/ListPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) AddPolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/AddPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) RemovePolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/RemovePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) ListTenants(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GroupNames, error) {
+	out := new(GroupNames)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/ListTenants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) CreateTenant(ctx context.Context, in *GroupName, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/CreateTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authAdminServiceClient) DeleteTenant(ctx context.Context, in *GroupName, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth.AuthAdminService/DeleteTenant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthAdminServiceServer is the server API for AuthAdminService service.
+// All implementations must embed UnimplementedAuthAdminServiceServer
+// for forward compatibility
+type AuthAdminServiceServer interface {
+	// List rpcs return entries in ascending order, see ListOptions.
+	ListUserGroups(context.Context, *ListRequest) (*GroupNames, error)
+	// Create rpcs take the description and owner of the group, the creation
+	// time and creator are set by the service.
+	CreateUserGroup(context.Context, *GroupInfo) (*emptypb.Empty, error)
+	// GetUserGroup pages through the direct members, inherited members are
+	// always listed completely.
+	GetUserGroup(context.Context, *GroupQuery) (*Group, error)
+	// Update rpcs change the description and owner of the group that are set
+	// in the request and keep the others.
+	UpdateUserGroup(context.Context, *UpdateGroupRequest) (*emptypb.Empty, error)
+	DeleteUserGroup(context.Context, *GroupName) (*emptypb.Empty, error)
+	// AddUserToGroup adds a time-bound membership if not_before or expires_at
+	// is set.
+	AddUserToGroup(context.Context, *Membership) (*emptypb.Empty, error)
+	RemoveUserFromGroup(context.Context, *Membership) (*emptypb.Empty, error)
+	RemoveUser(context.Context, *Member) (*emptypb.Empty, error)
+	GetGroupsForUser(context.Context, *Member) (*GroupNames, error)
+	// AddUserGroupToGroup nests the member group, rejecting cycles.
+	AddUserGroupToGroup(context.Context, *Membership) (*emptypb.Empty, error)
+	GetUserMemberships(context.Context, *Member) (*NestedMembership, error)
+	// ListTimeBoundMemberships includes memberships not in effect yet.
+	ListTimeBoundMemberships(context.Context, *emptypb.Empty) (*Memberships, error)
+	ListResourceGroups(context.Context, *ListRequest) (*GroupNames, error)
+	CreateResourceGroup(context.Context, *GroupInfo) (*emptypb.Empty, error)
+	GetResourceGroup(context.Context, *GroupQuery) (*Group, error)
+	UpdateResourceGroup(context.Context, *UpdateGroupRequest) (*emptypb.Empty, error)
+	DeleteResourceGroup(context.Context, *GroupName) (*emptypb.Empty, error)
+	AddResourceToGroup(context.Context, *Membership) (*emptypb.Empty, error)
+	RemoveResourceFromGroup(context.Context, *Membership) (*emptypb.Empty, error)
+	RemoveResource(context.Context, *Member) (*emptypb.Empty, error)
+	GetGroupsForResource(context.Context, *Member) (*GroupNames, error)
+	// ListPolicies includes time-bound policies not in effect yet. Filters
+	// match the user group or the resource group.
+	ListPolicies(context.Context, *ListRequest) (*Policies, error)
+	// AddPolicy adds a time-bound policy if not_before or expires_at is set.
+	AddPolicy(context.Context, *Policy) (*emptypb.Empty, error)
+	RemovePolicy(context.Context, *Policy) (*emptypb.Empty, error)
+	ListTenants(context.Context, *emptypb.Empty) (*GroupNames, error)
+	// CreateTenant creates the tenant and its admin group "<tenant>::admin".
+	CreateTenant(context.Context, *GroupName) (*emptypb.Empty, error)
+	// DeleteTenant removes the tenant with all of its groups and policies.
+	DeleteTenant(context.Context, *GroupName) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAuthAdminServiceServer()
+}
+
+// UnimplementedAuthAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAuthAdminServiceServer struct {
+}
+
+func (UnimplementedAuthAdminServiceServer) ListUserGroups(context.Context, *ListRequest) (*GroupNames, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserGroups not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) CreateUserGroup(context.Context, *GroupInfo) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUserGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) GetUserGroup(context.Context, *GroupQuery) (*Group, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) UpdateUserGroup(context.Context, *UpdateGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) DeleteUserGroup(context.Context, *GroupName) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) AddUserToGroup(context.Context, *Membership) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserToGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) RemoveUserFromGroup(context.Context, *Membership) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserFromGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) RemoveUser(context.Context, *Member) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUser not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) GetGroupsForUser(context.Context, *Member) (*GroupNames, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupsForUser not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) AddUserGroupToGroup(context.Context, *Membership) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserGroupToGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) GetUserMemberships(context.Context, *Member) (*NestedMembership, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserMemberships not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) ListTimeBoundMemberships(context.Context, *emptypb.Empty) (*Memberships, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTimeBoundMemberships not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) ListResourceGroups(context.Context, *ListRequest) (*GroupNames, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResourceGroups not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) CreateResourceGroup(context.Context, *GroupInfo) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateResourceGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) GetResourceGroup(context.Context, *GroupQuery) (*Group, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) UpdateResourceGroup(context.Context, *UpdateGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateResourceGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) DeleteResourceGroup(context.Context, *GroupName) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteResourceGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) AddResourceToGroup(context.Context, *Membership) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddResourceToGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) RemoveResourceFromGroup(context.Context, *Membership) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveResourceFromGroup not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) RemoveResource(context.Context, *Member) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveResource not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) GetGroupsForResource(context.Context, *Member) (*GroupNames, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupsForResource not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) ListPolicies(context.Context, *ListRequest) (*Policies, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPolicies not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) AddPolicy(context.Context, *Policy) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPolicy not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) RemovePolicy(context.Context, *Policy) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePolicy not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) ListTenants(context.Context, *emptypb.Empty) (*GroupNames, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenants not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) CreateTenant(context.Context, *GroupName) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTenant not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) DeleteTenant(context.Context, *GroupName) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTenant not implemented")
+}
+func (UnimplementedAuthAdminServiceServer) mustEmbedUnimplementedAuthAdminServiceServer() {}
+
+// UnsafeAuthAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthAdminServiceServer will
+// result in compilation errors.
+type UnsafeAuthAdminServiceServer interface {
+	mustEmbedUnimplementedAuthAdminServiceServer()
+}
+
+func RegisterAuthAdminServiceServer(s grpc.ServiceRegistrar, srv AuthAdminServiceServer) {
+	s.RegisterService(&AuthAdminService_ServiceDesc, srv)
+}
+
+func _AuthAdminService_ListUserGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).ListUserGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/ListUserGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).ListUserGroups(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_CreateUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).CreateUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/CreateUserGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).CreateUserGroup(ctx, req.(*GroupInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_GetUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).GetUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/GetUserGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).GetUserGroup(ctx, req.(*GroupQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_UpdateUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).UpdateUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/UpdateUserGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).UpdateUserGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_DeleteUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).DeleteUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/DeleteUserGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).DeleteUserGroup(ctx, req.(*GroupName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_AddUserToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Membership)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).AddUserToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/AddUserToGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).AddUserToGroup(ctx, req.(*Membership))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_RemoveUserFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Membership)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).RemoveUserFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/RemoveUserFromGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).RemoveUserFromGroup(ctx, req.(*Membership))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Member)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).RemoveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/RemoveUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).RemoveUser(ctx, req.(*Member))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_GetGroupsForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Member)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).GetGroupsForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/GetGroupsForUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).GetGroupsForUser(ctx, req.(*Member))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_AddUserGroupToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Membership)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).AddUserGroupToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/AddUserGroupToGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).AddUserGroupToGroup(ctx, req.(*Membership))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_GetUserMemberships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Member)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).GetUserMemberships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/GetUserMemberships",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).GetUserMemberships(ctx, req.(*Member))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_ListTimeBoundMemberships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).ListTimeBoundMemberships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/ListTimeBoundMemberships",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).ListTimeBoundMemberships(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_ListResourceGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).ListResourceGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/ListResourceGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).ListResourceGroups(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_CreateResourceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).CreateResourceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/CreateResourceGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).CreateResourceGroup(ctx, req.(*GroupInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_GetResourceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).GetResourceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/GetResourceGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).GetResourceGroup(ctx, req.(*GroupQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_UpdateResourceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).UpdateResourceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/UpdateResourceGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).UpdateResourceGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_DeleteResourceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).DeleteResourceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/DeleteResourceGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).DeleteResourceGroup(ctx, req.(*GroupName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_AddResourceToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Membership)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).AddResourceToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/AddResourceToGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).AddResourceToGroup(ctx, req.(*Membership))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_RemoveResourceFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Membership)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).RemoveResourceFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/RemoveResourceFromGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).RemoveResourceFromGroup(ctx, req.(*Membership))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_RemoveResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Member)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).RemoveResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/RemoveResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).RemoveResource(ctx, req.(*Member))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_GetGroupsForResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Member)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).GetGroupsForResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/GetGroupsForResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).GetGroupsForResource(ctx, req.(*Member))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_ListPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).ListPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/ListPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).ListPolicies(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_AddPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Policy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).AddPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/AddPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).AddPolicy(ctx, req.(*Policy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_RemovePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Policy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).RemovePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/RemovePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).RemovePolicy(ctx, req.(*Policy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_ListTenants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).ListTenants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/ListTenants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).ListTenants(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_CreateTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).CreateTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/CreateTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).CreateTenant(ctx, req.(*GroupName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthAdminService_DeleteTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthAdminServiceServer).DeleteTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth.AuthAdminService/DeleteTenant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthAdminServiceServer).DeleteTenant(ctx, req.(*GroupName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthAdminService_ServiceDesc is the grpc.ServiceDesc for AuthAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auth.AuthAdminService",
+	HandlerType: (*AuthAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUserGroups",
+			Handler:    _AuthAdminService_ListUserGroups_Handler,
+		},
+		{
+			MethodName: "CreateUserGroup",
+			Handler:    _AuthAdminService_CreateUserGroup_Handler,
+		},
+		{
+			MethodName: "GetUserGroup",
+			Handler:    _AuthAdminService_GetUserGroup_Handler,
+		},
+		{
+			MethodName: "UpdateUserGroup",
+			Handler:    _AuthAdminService_UpdateUserGroup_Handler,
+		},
+		{
+			MethodName: "DeleteUserGroup",
+			Handler:    _AuthAdminService_DeleteUserGroup_Handler,
+		},
+		{
+			MethodName: "AddUserToGroup",
+			Handler:    _AuthAdminService_AddUserToGroup_Handler,
+		},
+		{
+			MethodName: "RemoveUserFromGroup",
+			Handler:    _AuthAdminService_RemoveUserFromGroup_Handler,
+		},
+		{
+			MethodName: "RemoveUser",
+			Handler:    _AuthAdminService_RemoveUser_Handler,
+		},
+		{
+			MethodName: "GetGroupsForUser",
+			Handler:    _AuthAdminService_GetGroupsForUser_Handler,
+		},
+		{
+			MethodName: "AddUserGroupToGroup",
+			Handler:    _AuthAdminService_AddUserGroupToGroup_Handler,
+		},
+		{
+			MethodName: "GetUserMemberships",
+			Handler:    _AuthAdminService_GetUserMemberships_Handler,
+		},
+		{
+			MethodName: "ListTimeBoundMemberships",
+			Handler:    _AuthAdminService_ListTimeBoundMemberships_Handler,
+		},
+		{
+			MethodName: "ListResourceGroups",
+			Handler:    _AuthAdminService_ListResourceGroups_Handler,
+		},
+		{
+			MethodName: "CreateResourceGroup",
+			Handler:    _AuthAdminService_CreateResourceGroup_Handler,
+		},
+		{
+			MethodName: "GetResourceGroup",
+			Handler:    _AuthAdminService_GetResourceGroup_Handler,
+		},
+		{
+			MethodName: "UpdateResourceGroup",
+			Handler:    _AuthAdminService_UpdateResourceGroup_Handler,
+		},
+		{
+			MethodName: "DeleteResourceGroup",
+			Handler:    _AuthAdminService_DeleteResourceGroup_Handler,
+		},
+		{
+			MethodName: "AddResourceToGroup",
+			Handler:    _AuthAdminService_AddResourceToGroup_Handler,
+		},
+		{
+			MethodName: "RemoveResourceFromGroup",
+			Handler:    _AuthAdminService_RemoveResourceFromGroup_Handler,
+		},
+		{
+			MethodName: "RemoveResource",
+			Handler:    _AuthAdminService_RemoveResource_Handler,
+		},
+		{
+			MethodName: "GetGroupsForResource",
+			Handler:    _AuthAdminService_GetGroupsForResource_Handler,
+		},
+		{
+			MethodName: "ListPolicies",
+			Handler:    _AuthAdminService_ListPolicies_Handler,
+		},
+		{
+			MethodName: "AddPolicy",
+			Handler:    _AuthAdminService_AddPolicy_Handler,
+		},
+		{
+			MethodName: "RemovePolicy",
+			Handler:    _AuthAdminService_RemovePolicy_Handler,
+		},
+		{
+			MethodName: "ListTenants",
+			Handler:    _AuthAdminService_ListTenants_Handler,
+		},
+		{
+			MethodName: "CreateTenant",
+			Handler:    _AuthAdminService_CreateTenant_Handler,
+		},
+		{
+			MethodName: "DeleteTenant",
+			Handler:    _AuthAdminService_DeleteTenant_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auth-admin.proto",
+}